@@ -0,0 +1,400 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// maxBatchOperations is the most operations a batch can have across its creates,
+// updates and archives
+const maxBatchOperations = 500
+
+// batchOperation is one operation in a batch. field locates it in the request, e.g.
+// update[2], and status is what it returns when it succeeds.
+type batchOperation[T any] struct {
+	field  string
+	status int
+	run    func(ctx context.Context, repositories storage.RecordRepositories) (T, error)
+}
+
+// batchOutcome is what an operation in a batch returned, either the record or why
+// it failed
+type batchOutcome[T any] struct {
+	status int
+	record T
+	err    *models.Error
+}
+
+// runBatch runs a batch's operations in order. When atomic they're run in one
+// transaction that stops at the first failure, whose error is returned with its
+// field located in the request. Otherwise each is run on its own, and failures are
+// returned in their outcomes.
+func runBatch[T any](s *Server, r *http.Request, atomic bool, resource string, operations []batchOperation[T]) ([]batchOutcome[T], error) {
+	outcomes := make([]batchOutcome[T], len(operations))
+
+	if atomic {
+		err := s.transactor.InTx(r.Context(), func(repositories storage.RecordRepositories) error {
+			for i, op := range operations {
+				record, err := op.run(r.Context(), repositories)
+
+				if err != nil {
+					switch {
+					case isDataException(err):
+						s.log(r).Warn("Batch operation rejected by the database", "resource", resource, "field", op.field, "error", err)
+					case unmappedConstraint(err) != "":
+						s.log(r).Warn("Batch operation violated a constraint with no error message", "resource", resource, "field", op.field, "constraint", unmappedConstraint(err))
+					}
+
+					return batchError(op.field, err, resource)
+				}
+
+				outcomes[i] = batchOutcome[T]{status: op.status, record: record}
+			}
+
+			return nil
+		})
+
+		return outcomes, err
+	}
+
+	repositories := s.repositories()
+
+	for i, op := range operations {
+		record, err := op.run(r.Context(), repositories)
+
+		if err == nil {
+			outcomes[i] = batchOutcome[T]{status: op.status, record: record}
+			continue
+		}
+
+		// the rest of the batch can't run once the request has been cancelled
+		if r.Context().Err() != nil {
+			return nil, r.Context().Err()
+		}
+
+		apiError := mapError(err, resource)
+
+		switch {
+		case apiError.Code >= http.StatusInternalServerError:
+			s.log(r).Error("Batch operation failed", "resource", resource, "field", op.field, "error", err)
+		case isDataException(err):
+			s.log(r).Warn("Batch operation rejected by the database", "resource", resource, "field", op.field, "error", err)
+		case unmappedConstraint(err) != "":
+			s.log(r).Warn("Batch operation violated a constraint with no error message", "resource", resource, "field", op.field, "constraint", unmappedConstraint(err))
+		}
+
+		outcomes[i] = batchOutcome[T]{status: int(apiError.Code), err: &apiError}
+	}
+
+	return outcomes, nil
+}
+
+// batchError locates the error from an operation in the request, e.g. a missing
+// landlord_id in the third create is reported against create[2].landlord_id. Server
+// errors are returned as they are.
+func batchError(field string, err error, resource string) error {
+	apiError := mapError(err, resource)
+
+	if apiError.Code >= http.StatusInternalServerError {
+		return err
+	}
+
+	if apiError.Field != nil {
+		field += "." + *apiError.Field
+	}
+
+	apiError.Field = &field
+
+	return apiError
+}
+
+// batchOperations returns a batch's operations in the order they're run: creates,
+// then updates, then archives
+func batchOperations[T any, C any, U any](
+	creates []C,
+	updates []U,
+	archives []models.BatchArchive,
+	create func(ctx context.Context, repositories storage.RecordRepositories, payload C) (T, error),
+	update func(ctx context.Context, repositories storage.RecordRepositories, update U) (T, error),
+	archive func(ctx context.Context, repositories storage.RecordRepositories, archive models.BatchArchive) (T, error),
+) []batchOperation[T] {
+	operations := []batchOperation[T]{}
+
+	for i, payload := range creates {
+		operations = append(operations, batchOperation[T]{
+			field:  fmt.Sprintf("create[%d]", i),
+			status: http.StatusCreated,
+			run: func(ctx context.Context, repositories storage.RecordRepositories) (T, error) {
+				return create(ctx, repositories, payload)
+			},
+		})
+	}
+
+	for i, u := range updates {
+		operations = append(operations, batchOperation[T]{
+			field:  fmt.Sprintf("update[%d]", i),
+			status: http.StatusOK,
+			run: func(ctx context.Context, repositories storage.RecordRepositories) (T, error) {
+				return update(ctx, repositories, u)
+			},
+		})
+	}
+
+	for i, a := range archives {
+		operations = append(operations, batchOperation[T]{
+			field:  fmt.Sprintf("archive[%d]", i),
+			status: http.StatusOK,
+			run: func(ctx context.Context, repositories storage.RecordRepositories) (T, error) {
+				return archive(ctx, repositories, a)
+			},
+		})
+	}
+
+	return operations
+}
+
+// batchVersions returns the versions an update or archive in a batch is limited to
+// by its if_match, which is read the same as an If-Match header
+func batchVersions(tag *string) (storage.Versions, error) {
+	versions, ok := ifMatch(tag)
+
+	if !ok {
+		return nil, storage.ErrPreconditionFailed
+	}
+
+	return versions, nil
+}
+
+// batchSize checks the number of operations in a batch
+func batchSize(sizes ...int) error {
+	total := 0
+
+	for _, size := range sizes {
+		total += size
+	}
+
+	if total > maxBatchOperations {
+		return newError(http.StatusBadRequest, models.InvalidRequest, fmt.Sprintf("A batch can have at most %d operations", maxBatchOperations))
+	}
+
+	return nil
+}
+
+// batchCounts returns how many of a batch's operations succeeded and failed
+func batchCounts[T any](outcomes []batchOutcome[T]) (succeeded int32, failed int32) {
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+
+	return succeeded, failed
+}
+
+// recordRepository is the create, update and archive the landlord, property and
+// tenant repositories share
+type recordRepository[T any, C any, U any] interface {
+	Create(ctx context.Context, organisationID string, payload C) (T, error)
+	Update(ctx context.Context, organisationID string, id string, payload U, versions storage.Versions) (T, error)
+	Archive(ctx context.Context, organisationID string, id string, versions storage.Versions) (T, error)
+}
+
+// recordBatch is a batch request for landlords, properties or tenants. Their
+// request models all have this shape, so each is decoded into it.
+type recordBatch[C any, U any] struct {
+	Atomic  *bool                  `json:"atomic,omitempty"`
+	Create  []C                    `json:"create,omitempty"`
+	Update  []recordBatchUpdate[U] `json:"update,omitempty"`
+	Archive []models.BatchArchive  `json:"archive,omitempty"`
+}
+
+type recordBatchUpdate[U any] struct {
+	Id      openapi_types.UUID `json:"id"`
+	IfMatch *string            `json:"if_match,omitempty"`
+	Changes U                  `json:"changes"`
+}
+
+// recordBatchResults are the results of a batch of landlords, properties or tenants,
+// in the shape of their results models
+type recordBatchResults[R any] struct {
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+	Create    []R   `json:"create"`
+	Update    []R   `json:"update"`
+	Archive   []R   `json:"archive"`
+}
+
+// recordBatchEndpoint describes a resource's batch endpoint to serveRecordBatch
+type recordBatchEndpoint[T any, C any, U any, R any] struct {
+	resource   string
+	repository func(repositories storage.RecordRepositories) recordRepository[T, C, U]
+	updatedAt  func(record T) time.Time
+
+	// checkCreate and checkUpdate validate an operation before it's run. They can
+	// be nil.
+	checkCreate func(ctx context.Context, payload C) error
+	checkUpdate func(ctx context.Context, payload U) error
+
+	// result returns an operation's result, with the record and its ETag when it
+	// succeeded
+	result func(status int32, err *models.Error, record *T, tag *string) R
+}
+
+// serveRecordBatch creates, updates and archives a resource's records in one request
+func serveRecordBatch[T any, C any, U any, R any](s *Server, w http.ResponseWriter, r *http.Request, endpoint recordBatchEndpoint[T, C, U, R]) {
+	var batch recordBatch[C, U]
+	err := json.NewDecoder(r.Body).Decode(&batch)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	if err := batchSize(len(batch.Create), len(batch.Update), len(batch.Archive)); err != nil {
+		s.handleError(w, r, err, endpoint.resource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	operations := batchOperations(batch.Create, batch.Update, batch.Archive,
+		func(ctx context.Context, repositories storage.RecordRepositories, payload C) (T, error) {
+			if endpoint.checkCreate != nil {
+				if err := endpoint.checkCreate(ctx, payload); err != nil {
+					var record T
+					return record, err
+				}
+			}
+
+			return endpoint.repository(repositories).Create(ctx, organisationID, payload)
+		},
+		func(ctx context.Context, repositories storage.RecordRepositories, update recordBatchUpdate[U]) (T, error) {
+			var record T
+			versions, err := batchVersions(update.IfMatch)
+
+			if err != nil {
+				return record, err
+			}
+
+			if endpoint.checkUpdate != nil {
+				if err := endpoint.checkUpdate(ctx, update.Changes); err != nil {
+					return record, err
+				}
+			}
+
+			return endpoint.repository(repositories).Update(ctx, organisationID, update.Id.String(), update.Changes, versions)
+		},
+		func(ctx context.Context, repositories storage.RecordRepositories, archive models.BatchArchive) (T, error) {
+			versions, err := batchVersions(archive.IfMatch)
+
+			if err != nil {
+				var record T
+				return record, err
+			}
+
+			return endpoint.repository(repositories).Archive(ctx, organisationID, archive.Id.String(), versions)
+		},
+	)
+
+	outcomes, err := runBatch(s, r, batch.Atomic == nil || *batch.Atomic, endpoint.resource, operations)
+
+	if err != nil {
+		s.handleError(w, r, err, endpoint.resource)
+		return
+	}
+
+	results := func(outcomes []batchOutcome[T]) []R {
+		results := make([]R, len(outcomes))
+
+		for i, outcome := range outcomes {
+			if outcome.err != nil {
+				results[i] = endpoint.result(int32(outcome.status), outcome.err, nil, nil)
+				continue
+			}
+
+			tag := etag(endpoint.updatedAt(outcome.record))
+			results[i] = endpoint.result(int32(outcome.status), nil, &outcome.record, &tag)
+		}
+
+		return results
+	}
+
+	succeeded, failed := batchCounts(outcomes)
+	updatesFrom, archivesFrom := len(batch.Create), len(batch.Create)+len(batch.Update)
+
+	resp := recordBatchResults[R]{
+		Succeeded: succeeded,
+		Failed:    failed,
+		Create:    results(outcomes[:updatesFrom]),
+		Update:    results(outcomes[updatesFrom:archivesFrom]),
+		Archive:   results(outcomes[archivesFrom:]),
+	}
+
+	s.log(r).Debug("Batch Response", "resource", endpoint.resource, "succeeded", succeeded, "failed", failed)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// LandlordsBatch creates, updates and archives landlords in one request
+func (s *Server) LandlordsBatch(w http.ResponseWriter, r *http.Request) {
+	serveRecordBatch(s, w, r, recordBatchEndpoint[models.Landlord, models.CreateLandlord, models.UpdateLandlord, models.LandlordBatchResult]{
+		resource: landlordResource,
+		repository: func(repositories storage.RecordRepositories) recordRepository[models.Landlord, models.CreateLandlord, models.UpdateLandlord] {
+			return repositories.Landlords
+		},
+		updatedAt: func(landlord models.Landlord) time.Time { return landlord.UpdatedAt },
+		result: func(status int32, err *models.Error, landlord *models.Landlord, tag *string) models.LandlordBatchResult {
+			return models.LandlordBatchResult{Status: status, Error: err, Landlord: landlord, Etag: tag}
+		},
+	})
+}
+
+// PropertiesBatch creates, updates and archives properties in one request
+func (s *Server) PropertiesBatch(w http.ResponseWriter, r *http.Request) {
+	checkAssignee := s.assigneeCheck(r)
+
+	serveRecordBatch(s, w, r, recordBatchEndpoint[models.Property, models.CreateProperty, models.UpdateProperty, models.PropertyBatchResult]{
+		resource: propertyResource,
+		repository: func(repositories storage.RecordRepositories) recordRepository[models.Property, models.CreateProperty, models.UpdateProperty] {
+			return repositories.Properties
+		},
+		updatedAt: func(property models.Property) time.Time { return property.UpdatedAt },
+		checkCreate: func(ctx context.Context, payload models.CreateProperty) error {
+			return checkAssignee(ctx, payload.AssignedTo)
+		},
+		checkUpdate: func(ctx context.Context, payload models.UpdateProperty) error {
+			assignee, _ := payload.AssignedTo.Get()
+
+			return checkAssignee(ctx, &assignee)
+		},
+		result: func(status int32, err *models.Error, property *models.Property, tag *string) models.PropertyBatchResult {
+			return models.PropertyBatchResult{Status: status, Error: err, Property: property, Etag: tag}
+		},
+	})
+}
+
+// TenantsBatch creates, updates and archives tenants in one request
+func (s *Server) TenantsBatch(w http.ResponseWriter, r *http.Request) {
+	serveRecordBatch(s, w, r, recordBatchEndpoint[models.Tenant, models.CreateTenant, models.UpdateTenant, models.TenantBatchResult]{
+		resource: tenantResource,
+		repository: func(repositories storage.RecordRepositories) recordRepository[models.Tenant, models.CreateTenant, models.UpdateTenant] {
+			return repositories.Tenants
+		},
+		updatedAt: func(tenant models.Tenant) time.Time { return tenant.UpdatedAt },
+		result: func(status int32, err *models.Error, tenant *models.Tenant, tag *string) models.TenantBatchResult {
+			return models.TenantBatchResult{Status: status, Error: err, Tenant: tenant, Etag: tag}
+		},
+	})
+}
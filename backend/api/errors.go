@@ -0,0 +1,336 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/middleware"
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Resource names used in error messages
+const (
+	landlordResource           = "landlord"
+	propertyResource           = "property"
+	tenantResource             = "tenant"
+	organisationResource       = "organisation"
+	portalUserResource         = "portal user"
+	maintenanceRequestResource = "maintenance request"
+	paymentResource            = "payment"
+	searchResource             = "search"
+)
+
+// Postgres error codes that are caused by the request rather than the server
+const (
+	pgInvalidTextRepresentation = "22P02"
+	pgInvalidDatetimeFormat     = "22007"
+	pgDatetimeFieldOverflow     = "22008"
+	pgNumericValueOutOfRange    = "22003"
+	pgNotNullViolation          = "23502"
+	pgForeignKeyViolation       = "23503"
+	pgUniqueViolation           = "23505"
+	pgCheckViolation            = "23514"
+)
+
+// pgQueryCanceled is raised when a statement runs past statement_timeout
+const pgQueryCanceled = "57014"
+
+// statusClientClosedRequest is the non-standard status used when the client goes away
+// before the response is written
+const statusClientClosedRequest = 499
+
+type constraintError struct {
+	field   string
+	message string
+}
+
+// constraintErrors describes constraint violations by constraint name, so they can
+// be reported against the request field that caused them. References are scoped to
+// the organisation by composite foreign keys, so a record belonging to another
+// organisation is reported the same as a missing one.
+var constraintErrors = map[string]constraintError{
+	"properties_landlord_id_organisation_id_fkey":           {"landlord_id", "No landlord found with the specified landlord_id"},
+	"tenants_property_id_organisation_id_fkey":              {"property_id", "No property found with the specified property_id"},
+	"portal_users_landlord_id_organisation_id_fkey":         {"landlord_id", "No landlord found with the specified landlord_id"},
+	"portal_users_tenant_id_organisation_id_fkey":           {"tenant_id", "No tenant found with the specified tenant_id"},
+	"portal_users_organisation_id_user_id_key":              {"user_id", "This user already has portal access in this organisation"},
+	"portal_users_landlord_or_tenant_check":                 {"landlord_id", "Exactly one of landlord_id or tenant_id must be given"},
+	"maintenance_requests_property_id_organisation_id_fkey": {"property_id", "No property found with the specified property_id"},
+	"maintenance_requests_tenant_id_organisation_id_fkey":   {"tenant_id", "No tenant found with the specified tenant_id"},
+	"payments_amount_check":                                 {"amount", "Amount must be more than 0"},
+}
+
+// dataExceptionErrors are the messages for values the database couldn't convert,
+// by error code. Postgres' own messages name types and echo the value, so they're
+// logged rather than returned. The column is reported when Postgres knows it.
+var dataExceptionErrors = map[string]struct {
+	message      string
+	fieldMessage string
+}{
+	pgInvalidTextRepresentation: {"A value isn't in the right format", "%s isn't in the right format"},
+	pgInvalidDatetimeFormat:     {"A date isn't in the right format", "%s must be a date in the format YYYY-MM-DD"},
+	pgDatetimeFieldOverflow:     {"A date is out of range", "%s is out of range"},
+	pgNumericValueOutOfRange:    {"A number is out of range", "%s is out of range"},
+}
+
+// notNullErrors overrides the message for NOT NULL violations by column name
+var notNullErrors = map[string]string{
+	"management_fee": "management_fee is required when the organisation has no default management fee",
+}
+
+func newError(code int, errorCode models.ErrorCode, message string) models.Error {
+	return models.Error{Code: int32(code), ErrorCode: errorCode, Message: message}
+}
+
+func newFieldError(code int, errorCode models.ErrorCode, field string, message string) models.Error {
+	return models.Error{Code: int32(code), ErrorCode: errorCode, Message: message, Field: &field}
+}
+
+// invalidRequestError is returned when the request body can't be decoded
+func invalidRequestError(err error) models.Error {
+	return newError(http.StatusBadRequest, models.InvalidRequest, err.Error())
+}
+
+// validationError is returned when a field in the request has an invalid value
+func validationError(field string, message string) models.Error {
+	return newFieldError(http.StatusUnprocessableEntity, models.ValidationFailed, field, message)
+}
+
+func timeoutError() models.Error {
+	return newError(http.StatusGatewayTimeout, models.Timeout, "The request took too long to complete")
+}
+
+func internalServerError() models.Error {
+	return newError(http.StatusInternalServerError, models.InternalError, "Internal server error")
+}
+
+// validateID checks that a path ID is a UUID before it's used in a query
+func validateID(id string, resource string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return newError(http.StatusBadRequest, models.InvalidId, fmt.Sprintf("Invalid %s ID format - must be a valid UUID", resource))
+	}
+
+	return nil
+}
+
+// mapError converts an error returned while handling a request for the given
+// resource into an API error
+func mapError(err error, resource string) models.Error {
+	var apiError models.Error
+	if errors.As(err, &apiError) {
+		return apiError
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return newError(statusClientClosedRequest, models.RequestCancelled, "The request was cancelled")
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return timeoutError()
+	}
+
+	if errors.Is(err, storage.ErrInvalidCursor) {
+		return newError(http.StatusBadRequest, models.InvalidRequest, "The cursor is invalid, or was issued for a list in a different order")
+	}
+
+	if errors.Is(err, storage.ErrInvalidSort) {
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, "sort", fmt.Sprintf("The %s list can't be sorted by the given fields, or a field was given more than once", resource))
+	}
+
+	if errors.Is(err, storage.ErrInvalidFields) {
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, "fields", fmt.Sprintf("The %s has no field with one of the given names", resource))
+	}
+
+	if errors.Is(err, storage.ErrPreconditionFailed) {
+		return newError(http.StatusPreconditionFailed, models.PreconditionFailed, fmt.Sprintf("The %s has changed since the version in If-Match. Get it again for its current ETag.", resource))
+	}
+
+	if errors.Is(err, storage.ErrIdempotencyKeyReused) {
+		return newFieldError(http.StatusUnprocessableEntity, models.ValidationFailed, "Idempotency-Key", "The Idempotency-Key was already used for a different request. Use a new key for each request.")
+	}
+
+	if errors.Is(err, storage.ErrIdempotencyKeyInUse) {
+		return newFieldError(http.StatusConflict, models.Conflict, "Idempotency-Key", "The first request with this Idempotency-Key hasn't finished. Retry once it has.")
+	}
+
+	if errors.Is(err, storage.ErrPortalOrganisationRequired) {
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, "Portal-Organisation", "This user has portal access in more than one organisation. Give the one to use in Portal-Organisation.")
+	}
+
+	if errors.Is(err, storage.ErrTenancyEnded) {
+		return newError(http.StatusForbidden, models.Forbidden, "Only tenants whose tenancy is current can lodge maintenance requests")
+	}
+
+	if errors.Is(err, storage.ErrAlreadyPaid) {
+		return validationError("paid_to", "The tenant is already paid to this day. A payment must pay them past their current paid_to.")
+	}
+
+	if errors.Is(err, storage.ErrNotFound) {
+		return newError(http.StatusNotFound, models.NotFound, fmt.Sprintf("No %s found with the specified ID", resource))
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return internalServerError()
+	}
+
+	if c, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		switch pgErr.Code {
+		case pgForeignKeyViolation, pgCheckViolation:
+			return validationError(c.field, c.message)
+		case pgUniqueViolation:
+			return newFieldError(http.StatusConflict, models.Conflict, c.field, c.message)
+		}
+	}
+
+	switch pgErr.Code {
+	case pgQueryCanceled:
+		return timeoutError()
+	case pgInvalidTextRepresentation, pgInvalidDatetimeFormat, pgDatetimeFieldOverflow, pgNumericValueOutOfRange:
+		e := dataExceptionErrors[pgErr.Code]
+		if pgErr.ColumnName == "" {
+			return newError(http.StatusUnprocessableEntity, models.ValidationFailed, e.message)
+		}
+		return validationError(pgErr.ColumnName, fmt.Sprintf(e.fieldMessage, pgErr.ColumnName))
+	case pgNotNullViolation:
+		message, ok := notNullErrors[pgErr.ColumnName]
+		if !ok {
+			message = fmt.Sprintf("%s is required", pgErr.ColumnName)
+		}
+		return validationError(pgErr.ColumnName, message)
+	// constraints without an entry in constraintErrors get a fixed message, as their
+	// names describe the schema. handleError logs the name so an entry can be added.
+	case pgForeignKeyViolation:
+		return newError(http.StatusUnprocessableEntity, models.ValidationFailed, fmt.Sprintf("The %s refers to a record that doesn't exist", resource))
+	case pgCheckViolation:
+		return newError(http.StatusUnprocessableEntity, models.ValidationFailed, fmt.Sprintf("The %s has a value that isn't allowed", resource))
+	case pgUniqueViolation:
+		return newError(http.StatusConflict, models.Conflict, fmt.Sprintf("The %s conflicts with an existing %s", resource, resource))
+	}
+
+	return internalServerError()
+}
+
+// writeError writes an API error as the JSON response. The request ID is read back
+// from the response header set by middleware.RequestIDMiddleware, as the validator's
+// error handler isn't given the request.
+func writeError(w http.ResponseWriter, apiError models.Error) {
+	if requestID := w.Header().Get(middleware.RequestIDHeader); requestID != "" {
+		apiError.RequestId = &requestID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(apiError.Code))
+	json.NewEncoder(w).Encode(apiError)
+}
+
+// handleError maps err to an API error and writes it, logging anything that
+// isn't the client's fault
+func (s *Server) handleError(w http.ResponseWriter, r *http.Request, err error, resource string) {
+	apiError := mapError(err, resource)
+
+	switch {
+	case apiError.Code >= http.StatusInternalServerError:
+		s.log(r).Error("Request failed", "resource", resource, "error", err)
+	case isDataException(err):
+		s.log(r).Warn("Request rejected by the database", "resource", resource, "error", err)
+	case unmappedConstraint(err) != "":
+		s.log(r).Warn("Request violated a constraint with no error message", "resource", resource, "constraint", unmappedConstraint(err))
+	default:
+		s.log(r).Debug("Request rejected", "resource", resource, "error", err)
+	}
+
+	writeError(w, apiError)
+}
+
+// isDataException reports whether the database rejected a value that got past the
+// request validation. The client only gets a fixed message, and the SQLSTATE and
+// column are logged to find the gap in validation, without the rejected value.
+func isDataException(err error) bool {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	_, ok := dataExceptionErrors[pgErr.Code]
+
+	return ok
+}
+
+// unmappedConstraint returns the name of the constraint err violated when it has no
+// entry in constraintErrors, or ""
+func unmappedConstraint(err error) string {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+
+	switch pgErr.Code {
+	case pgForeignKeyViolation, pgCheckViolation, pgUniqueViolation:
+		if _, ok := constraintErrors[pgErr.ConstraintName]; !ok {
+			return pgErr.ConstraintName
+		}
+	}
+
+	return ""
+}
+
+// HandleParamError writes parameter binding errors from the generated router in
+// the same format as the handlers, against the parameter that couldn't be bound. The
+// binder's own messages can echo the value, so they aren't returned.
+func HandleParamError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, paramError(err))
+}
+
+func paramError(err error) models.Error {
+	var (
+		required    *RequiredParamError
+		header      *RequiredHeaderError
+		format      *InvalidParamFormatError
+		unmarshal   *UnmarshalingParamError
+		tooMany     *TooManyValuesForParamError
+		cookieParam *UnescapedCookieParamError
+	)
+
+	switch {
+	case errors.As(err, &required):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, required.ParamName, fmt.Sprintf("%s is required", required.ParamName))
+	case errors.As(err, &header):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, header.ParamName, fmt.Sprintf("The %s header is required", header.ParamName))
+	case errors.As(err, &format):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, format.ParamName, fmt.Sprintf("%s isn't in the right format", format.ParamName))
+	case errors.As(err, &unmarshal):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, unmarshal.ParamName, fmt.Sprintf("%s isn't in the right format", unmarshal.ParamName))
+	case errors.As(err, &tooMany):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, tooMany.ParamName, fmt.Sprintf("%s can only be given once", tooMany.ParamName))
+	case errors.As(err, &cookieParam):
+		return newFieldError(http.StatusBadRequest, models.InvalidRequest, cookieParam.ParamName, fmt.Sprintf("%s isn't in the right format", cookieParam.ParamName))
+	}
+
+	return newError(http.StatusBadRequest, models.InvalidRequest, "A parameter isn't in the right format")
+}
+
+// HandleValidationError writes OpenAPI request validation errors in the same
+// format as the handlers
+func HandleValidationError(w http.ResponseWriter, message string, statusCode int) {
+	errorCode := models.InvalidRequest
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		errorCode = models.Unauthorized
+	case http.StatusForbidden:
+		errorCode = models.Forbidden
+	case http.StatusNotFound:
+		errorCode = models.NotFound
+	}
+
+	writeError(w, newError(statusCode, errorCode, message))
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      int32
+		errorCode models.ErrorCode
+	}{
+		{"not found", storage.ErrNotFound, http.StatusNotFound, models.NotFound},
+		{"invalid cursor", storage.ErrInvalidCursor, http.StatusBadRequest, models.InvalidRequest},
+		{"invalid sort", storage.ErrInvalidSort, http.StatusBadRequest, models.InvalidRequest},
+		{"invalid fields", storage.ErrInvalidFields, http.StatusBadRequest, models.InvalidRequest},
+		{"precondition failed", storage.ErrPreconditionFailed, http.StatusPreconditionFailed, models.PreconditionFailed},
+		{"idempotency key reused", storage.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, models.ValidationFailed},
+		{"portal organisation required", storage.ErrPortalOrganisationRequired, http.StatusBadRequest, models.InvalidRequest},
+		{"idempotency key in use", storage.ErrIdempotencyKeyInUse, http.StatusConflict, models.Conflict},
+		{"tenancy ended", storage.ErrTenancyEnded, http.StatusForbidden, models.Forbidden},
+		{"already paid", storage.ErrAlreadyPaid, http.StatusUnprocessableEntity, models.ValidationFailed},
+		{"cancelled", fmt.Errorf("query: %w", context.Canceled), statusClientClosedRequest, models.RequestCancelled},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, models.Timeout},
+		{"statement timeout", &pgconn.PgError{Code: pgQueryCanceled}, http.StatusGatewayTimeout, models.Timeout},
+		{"unique violation", &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "portal_users_organisation_id_user_id_key"}, http.StatusConflict, models.Conflict},
+		{"not null violation", &pgconn.PgError{Code: pgNotNullViolation, ColumnName: "management_fee"}, http.StatusUnprocessableEntity, models.ValidationFailed},
+		{"unknown", fmt.Errorf("connection refused"), http.StatusInternalServerError, models.InternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapError(tt.err, landlordResource)
+
+			if got.Code != tt.code || got.ErrorCode != tt.errorCode {
+				t.Errorf("expected %d %s, got %d %s", tt.code, tt.errorCode, got.Code, got.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestMapDataException(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: pgInvalidTextRepresentation, Message: `invalid input syntax for type uuid: "abc"`}
+
+	got := mapError(pgErr, landlordResource)
+
+	if got.Code != http.StatusUnprocessableEntity || got.Message != "A value isn't in the right format" || got.Field != nil {
+		t.Errorf("expected a fixed message without the database's, got %+v", got)
+	}
+
+	pgErr = &pgconn.PgError{Code: pgInvalidDatetimeFormat, Message: `invalid input syntax for type date: "abc"`, ColumnName: "paid_to"}
+
+	got = mapError(pgErr, tenantResource)
+
+	if got.Field == nil || *got.Field != "paid_to" || got.Message != "paid_to must be a date in the format YYYY-MM-DD" {
+		t.Errorf("expected the error against the column, got %+v", got)
+	}
+
+	if !isDataException(fmt.Errorf("update: %w", pgErr)) || isDataException(storage.ErrNotFound) {
+		t.Error("expected only conversion errors from the database to be data exceptions")
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		field string
+	}{
+		{"field error", &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "properties_landlord_id_organisation_id_fkey"}, "create[2].landlord_id"},
+		{"record error", storage.ErrNotFound, "create[2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapError(batchError("create[2]", tt.err, propertyResource), propertyResource)
+
+			if got.Field == nil || *got.Field != tt.field {
+				t.Errorf("expected field %s, got %v", tt.field, got.Field)
+			}
+		})
+	}
+
+	if err := batchError("create[2]", fmt.Errorf("connection refused"), propertyResource); mapError(err, propertyResource).Field != nil {
+		t.Error("expected server errors not to be located in the request")
+	}
+}
+
+func TestMapUnmappedConstraint(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: pgCheckViolation, ConstraintName: "landlords_secret_check"}
+
+	got := mapError(pgErr, landlordResource)
+
+	if got.Code != http.StatusUnprocessableEntity || strings.Contains(got.Message, pgErr.ConstraintName) {
+		t.Errorf("expected a fixed message without the constraint name, got %+v", got)
+	}
+
+	if name := unmappedConstraint(pgErr); name != pgErr.ConstraintName {
+		t.Errorf("expected the constraint to be reported for logging, got %q", name)
+	}
+
+	if name := unmappedConstraint(&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "payments_amount_check"}); name != "" {
+		t.Errorf("expected a mapped constraint not to be reported, got %q", name)
+	}
+}
+
+func TestParamError(t *testing.T) {
+	err := &InvalidParamFormatError{ParamName: "id", Err: errors.New(`invalid UUID "<script>"`)}
+
+	got := paramError(fmt.Errorf("binding: %w", err))
+
+	if got.Code != http.StatusBadRequest || got.Field == nil || *got.Field != "id" || strings.Contains(got.Message, "<script>") {
+		t.Errorf("expected a field error on id without the value, got %+v", got)
+	}
+
+	got = paramError(&RequiredParamError{ParamName: "from"})
+
+	if got.Field == nil || *got.Field != "from" || got.Message != "from is required" {
+		t.Errorf("expected a required error on from, got %+v", got)
+	}
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/storage"
+)
+
+// etag returns the entity tag for a record at the version given by its updated_at
+func etag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// weakETag returns the entity tag for a representation built from several versions,
+// e.g. a page of a list, which changes when any of them do. It's weak as it
+// identifies the records rather than the exact bytes of the response.
+func weakETag(versions ...int64) string {
+	parts := make([]string, len(versions))
+
+	for i, version := range versions {
+		parts[i] = strconv.FormatInt(version, 36)
+	}
+
+	return `W/"` + strings.Join(parts, "-") + `"`
+}
+
+// parseETag returns the updated_at of the version an entity tag was made for. Weak
+// tags aren't parsed, as If-Match only matches strong ones.
+func parseETag(tag string) (time.Time, bool) {
+	value, ok := strings.CutPrefix(tag, `"`)
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	value, ok = strings.CutSuffix(value, `"`)
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	micros, err := strconv.ParseInt(value, 36, 64)
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.UnixMicro(micros).UTC(), true
+}
+
+// ifMatch returns the versions a write is limited to by the If-Match header. Without
+// the header, or with *, any version can be written. ok is false when none of the
+// tags can match a version, so the precondition fails without a query.
+func ifMatch(header *string) (versions storage.Versions, ok bool) {
+	if header == nil {
+		return nil, true
+	}
+
+	for _, tag := range strings.Split(*header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "*" {
+			return nil, true
+		}
+
+		if updatedAt, ok := parseETag(tag); ok {
+			versions = append(versions, updatedAt)
+		}
+	}
+
+	return versions, len(versions) > 0
+}
+
+// validators are the headers a client revalidates its copy of a response with
+type validators struct {
+	etag         string
+	lastModified time.Time
+}
+
+// recordValidators are the validators for a record, and the records expanded into it.
+// With nothing expanded the ETag is the record's version, which If-Match accepts.
+func recordValidators(updatedAt time.Time, expanded ...time.Time) validators {
+	if len(expanded) == 0 {
+		return validators{etag: etag(updatedAt), lastModified: updatedAt}
+	}
+
+	v := validators{lastModified: updatedAt}
+	versions := []int64{updatedAt.UnixMicro()}
+
+	for _, t := range expanded {
+		versions = append(versions, t.UnixMicro())
+
+		if t.After(v.lastModified) {
+			v.lastModified = t
+		}
+	}
+
+	v.etag = weakETag(versions...)
+
+	return v
+}
+
+// withFields returns the validators for a record limited to a sparse fieldset. The
+// ETag is weak, as the response is only part of the record, and changes with the
+// fields asked for.
+func (v validators) withFields(fields []string) validators {
+	if len(fields) == 0 {
+		return v
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "fields=%s", sortedSet(fields))
+
+	tag := strings.Trim(strings.TrimPrefix(v.etag, "W/"), `"`)
+	v.etag = `W/"` + tag + "-" + strconv.FormatInt(int64(h.Sum64()>>1), 36) + `"`
+
+	return v
+}
+
+// listShape is what a list response holds besides the records matching the filter:
+// which page of them, in what order, and which of their fields
+type listShape struct {
+	page   storage.Page
+	sort   []string
+	fields []string
+	expand []string
+}
+
+// hash identifies the shape in a list's ETag. fields and expand are sets, so their
+// order doesn't change it, but the order of the sort fields does.
+func (s listShape) hash() int64 {
+	h := fnv.New64a()
+
+	fmt.Fprintf(h, "limit=%d", s.page.Limit)
+
+	switch {
+	case s.page.After != nil:
+		fmt.Fprintf(h, "&after=%s", *s.page.After)
+	case s.page.Before != nil:
+		fmt.Fprintf(h, "&before=%s", *s.page.Before)
+	default:
+		fmt.Fprintf(h, "&offset=%d", s.page.Offset)
+	}
+
+	fmt.Fprintf(h, "&sort=%s&fields=%s&expand=%s", strings.Join(s.sort, ","), sortedSet(s.fields), sortedSet(s.expand))
+
+	// kept positive so it's formatted without a sign
+	return int64(h.Sum64() >> 1)
+}
+
+// sortedSet joins the distinct values in sorted order
+func sortedSet(values []string) string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	return strings.Join(slices.Compact(values), ",")
+}
+
+// expandNames returns the values of an expand param as strings
+func expandNames[T ~string](expand *[]T) []string {
+	if expand == nil {
+		return nil
+	}
+
+	names := make([]string, len(*expand))
+
+	for i, e := range *expand {
+		names[i] = string(e)
+	}
+
+	return names
+}
+
+// listValidators are the validators for a page of a list. The ETag changes with the
+// records matching the filter, with any change to the expanded records, which are
+// versioned as a whole as they aren't filtered, and with the page, order and fields
+// asked for.
+func listValidators(version storage.ListVersion, shape listShape, expanded ...storage.ListVersion) validators {
+	v := validators{lastModified: version.LastModified}
+	versions := []int64{micros(version.UpdatedAt), int64(version.Count), shape.hash()}
+
+	for _, e := range expanded {
+		versions = append(versions, micros(e.LastModified))
+
+		if e.LastModified.After(v.lastModified) {
+			v.lastModified = e.LastModified
+		}
+	}
+
+	v.etag = weakETag(versions...)
+
+	return v
+}
+
+// micros returns a time as microseconds since the epoch, or 0 for the zero time of an
+// empty list
+func micros(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixMicro()
+}
+
+// set adds the validators to a response. The response can be stored, but only by
+// the client, and must be revalidated before it's used.
+func (v validators) set(w http.ResponseWriter) {
+	w.Header().Set("ETag", v.etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if !v.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", v.lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// notModified writes a 304 when the client's copy is current, going by If-None-Match,
+// or If-Modified-Since when there's no If-None-Match
+func (v validators) notModified(w http.ResponseWriter, ifNoneMatch *string, ifModifiedSince *string) bool {
+	current := false
+
+	if ifNoneMatch != nil {
+		current = inNoneMatch(*ifNoneMatch, v.etag)
+	} else if ifModifiedSince != nil && !v.lastModified.IsZero() {
+		since, err := http.ParseTime(*ifModifiedSince)
+		// HTTP dates are to the second, so the last change is too
+		current = err == nil && !v.lastModified.Truncate(time.Second).After(since)
+	}
+
+	if current {
+		v.set(w)
+		w.WriteHeader(http.StatusNotModified)
+	}
+
+	return current
+}
+
+// inNoneMatch reports whether an If-None-Match header matches the ETag. It compares
+// weakly, so a W/ prefix on either is ignored.
+func inNoneMatch(header string, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+
+		if t == "*" || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
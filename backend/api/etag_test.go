@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/storage"
+)
+
+func TestIfMatch(t *testing.T) {
+	updatedAt := time.Date(2025, 7, 1, 9, 30, 0, 123456000, time.UTC)
+	tag := etag(updatedAt)
+
+	header := func(value string) *string {
+		return &value
+	}
+
+	tests := []struct {
+		name     string
+		header   *string
+		versions int
+		ok       bool
+	}{
+		{"no header", nil, 0, true},
+		{"any version", header("*"), 0, true},
+		{"one tag", header(tag), 1, true},
+		{"several tags", header(tag + ` , "1"`), 2, true},
+		{"weak tag", header("W/" + tag), 0, false},
+		{"malformed tag", header("not-a-tag"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, ok := ifMatch(tt.header)
+
+			if ok != tt.ok || len(versions) != tt.versions {
+				t.Fatalf("expected %d versions and ok %v, got %v and %v", tt.versions, tt.ok, versions, ok)
+			}
+
+			if tt.versions > 0 && !versions[0].Equal(updatedAt) {
+				t.Errorf("expected the tag to parse back to %v, got %v", updatedAt, versions[0])
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	updatedAt := time.Date(2025, 7, 1, 9, 30, 0, 123456000, time.UTC)
+	v := recordValidators(updatedAt)
+
+	header := func(value string) *string {
+		return &value
+	}
+
+	tests := []struct {
+		name            string
+		ifNoneMatch     *string
+		ifModifiedSince *string
+		want            bool
+	}{
+		{"no headers", nil, nil, false},
+		{"matching tag", header(`"1", ` + v.etag), nil, true},
+		{"weak match", header("W/" + v.etag), nil, true},
+		{"any tag", header("*"), nil, true},
+		{"other tag", header(`"1"`), nil, false},
+		{"same second", nil, header("Tue, 01 Jul 2025 09:30:00 GMT"), true},
+		{"second before", nil, header("Tue, 01 Jul 2025 09:29:59 GMT"), false},
+		{"malformed date", nil, header("yesterday"), false},
+		{"If-None-Match takes precedence", header(`"1"`), header("Tue, 01 Jul 2025 09:30:00 GMT"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			if got := v.notModified(rec, tt.ifNoneMatch, tt.ifModifiedSince); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+
+			if tt.want && (rec.Code != http.StatusNotModified || rec.Header().Get("ETag") != v.etag) {
+				t.Errorf("expected a 304 with the ETag, got %d with %q", rec.Code, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestRecordValidators(t *testing.T) {
+	updatedAt := time.Date(2025, 7, 1, 9, 30, 0, 0, time.UTC)
+	landlordUpdatedAt := updatedAt.Add(time.Hour)
+
+	v := recordValidators(updatedAt, landlordUpdatedAt)
+
+	if _, ok := parseETag(v.etag); ok {
+		t.Errorf("expected a weak ETag with expanded records, got %s", v.etag)
+	}
+
+	if !v.lastModified.Equal(landlordUpdatedAt) {
+		t.Errorf("expected the latest change, %v, got %v", landlordUpdatedAt, v.lastModified)
+	}
+}
+
+func TestListValidators(t *testing.T) {
+	version := storage.ListVersion{Count: 3, UpdatedAt: time.Date(2025, 7, 1, 9, 30, 0, 0, time.UTC)}
+	version.LastModified = version.UpdatedAt
+
+	cursor := "abc"
+	first := listShape{page: storage.Page{Limit: 20}, sort: []string{"name"}, fields: []string{"name", "email"}}
+	tag := listValidators(version, first).etag
+
+	tests := []struct {
+		name  string
+		shape listShape
+		same  bool
+	}{
+		{"same shape", first, true},
+		{"fields in another order", listShape{page: first.page, sort: first.sort, fields: []string{"email", "name"}}, true},
+		{"next page", listShape{page: storage.Page{Limit: 20, Offset: 20}, sort: first.sort, fields: first.fields}, false},
+		{"after a cursor", listShape{page: storage.Page{Limit: 20, After: &cursor}, sort: first.sort, fields: first.fields}, false},
+		{"smaller page", listShape{page: storage.Page{Limit: 10}, sort: first.sort, fields: first.fields}, false},
+		{"another order", listShape{page: first.page, sort: []string{"-name"}, fields: first.fields}, false},
+		{"other fields", listShape{page: first.page, sort: first.sort, fields: []string{"name"}}, false},
+		{"expanded", listShape{page: first.page, sort: first.sort, fields: first.fields, expand: []string{"landlord"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listValidators(version, tt.shape).etag; (got == tag) != tt.same {
+				t.Errorf("expected the ETags to match to be %v, got %s and %s", tt.same, tag, got)
+			}
+		})
+	}
+}
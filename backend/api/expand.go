@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+)
+
+// expandProperties includes each property's landlord when the expand param asks for
+// it. Related records are fetched in one query for the whole page rather than one
+// per property.
+func (s *Server) expandProperties(ctx context.Context, organisationID string, properties []models.Property, expand *[]models.PropertyExpand) error {
+	if expand == nil || !slices.Contains(*expand, models.PropertyExpandLandlord) {
+		return nil
+	}
+
+	ids := []string{}
+
+	for _, property := range properties {
+		ids = append(ids, property.LandlordId.String())
+	}
+
+	landlords, err := s.landlords.GetMany(ctx, organisationID, uniqueIDs(ids))
+
+	if err != nil {
+		return err
+	}
+
+	byID := map[string]models.Landlord{}
+
+	for _, landlord := range landlords {
+		byID[landlord.Id.String()] = landlord
+	}
+
+	for i, property := range properties {
+		if landlord, ok := byID[property.LandlordId.String()]; ok {
+			properties[i].Landlord = &landlord
+		}
+	}
+
+	return nil
+}
+
+// expandTenants includes each tenant's property, and the property's landlord, when
+// the expand param asks for them
+func (s *Server) expandTenants(ctx context.Context, organisationID string, tenants []models.Tenant, expand *[]models.TenantExpand) error {
+	if expand == nil {
+		return nil
+	}
+
+	withLandlord := slices.Contains(*expand, models.TenantExpandPropertyLandlord)
+
+	if !withLandlord && !slices.Contains(*expand, models.TenantExpandProperty) {
+		return nil
+	}
+
+	ids := []string{}
+
+	for _, tenant := range tenants {
+		ids = append(ids, tenant.PropertyId.String())
+	}
+
+	properties, err := s.properties.GetMany(ctx, organisationID, uniqueIDs(ids))
+
+	if err != nil {
+		return err
+	}
+
+	if withLandlord {
+		expandLandlord := []models.PropertyExpand{models.PropertyExpandLandlord}
+
+		if err := s.expandProperties(ctx, organisationID, properties, &expandLandlord); err != nil {
+			return err
+		}
+	}
+
+	byID := map[string]models.Property{}
+
+	for _, property := range properties {
+		byID[property.Id.String()] = property
+	}
+
+	for i, tenant := range tenants {
+		if property, ok := byID[tenant.PropertyId.String()]; ok {
+			tenants[i].Property = &property
+		}
+	}
+
+	return nil
+}
+
+// propertyExpandVersions returns the versions of the records the expand param adds
+// to a list of properties, so the list's ETag changes with them
+func (s *Server) propertyExpandVersions(ctx context.Context, organisationID string, expand *[]models.PropertyExpand) ([]storage.ListVersion, error) {
+	if expand == nil || !slices.Contains(*expand, models.PropertyExpandLandlord) {
+		return nil, nil
+	}
+
+	version, err := s.landlords.ListVersion(ctx, organisationID, storage.LandlordFilter{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []storage.ListVersion{version}, nil
+}
+
+// tenantExpandVersions returns the versions of the records the expand param adds to a
+// list of tenants
+func (s *Server) tenantExpandVersions(ctx context.Context, organisationID string, expand *[]models.TenantExpand) ([]storage.ListVersion, error) {
+	if expand == nil {
+		return nil, nil
+	}
+
+	withLandlord := slices.Contains(*expand, models.TenantExpandPropertyLandlord)
+
+	if !withLandlord && !slices.Contains(*expand, models.TenantExpandProperty) {
+		return nil, nil
+	}
+
+	version, err := s.properties.ListVersion(ctx, organisationID, storage.PropertyFilter{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []storage.ListVersion{version}
+
+	if withLandlord {
+		expandLandlord := []models.PropertyExpand{models.PropertyExpandLandlord}
+		landlordVersions, err := s.propertyExpandVersions(ctx, organisationID, &expandLandlord)
+
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, landlordVersions...)
+	}
+
+	return versions, nil
+}
+
+// propertyValidators are the validators for a property and its expanded landlord
+func propertyValidators(property models.Property) validators {
+	expanded := []time.Time{}
+
+	if property.Landlord != nil {
+		expanded = append(expanded, property.Landlord.UpdatedAt)
+	}
+
+	return recordValidators(property.UpdatedAt, expanded...)
+}
+
+// tenantValidators are the validators for a tenant and its expanded property and
+// landlord
+func tenantValidators(tenant models.Tenant) validators {
+	expanded := []time.Time{}
+
+	if tenant.Property != nil {
+		expanded = append(expanded, tenant.Property.UpdatedAt)
+
+		if tenant.Property.Landlord != nil {
+			expanded = append(expanded, tenant.Property.Landlord.UpdatedAt)
+		}
+	}
+
+	return recordValidators(tenant.UpdatedAt, expanded...)
+}
+
+// uniqueIDs removes repeated IDs, e.g. from several tenants of the same property
+func uniqueIDs(ids []string) []string {
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
@@ -1,3 +1,4 @@
 package api
 
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config ../oapi-models-config.yaml ../../schema/openapi.yaml
 //go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config ../oapi-config.yaml ../../schema/openapi.yaml
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// idempotencyClaim is a create request holding its Idempotency-Key until it finishes
+type idempotencyClaim struct {
+	organisationID string
+	key            string
+}
+
+// claimIdempotencyKey reserves the request's Idempotency-Key, if it has one. When ok
+// is false a response has been written, either the first response to a retried
+// request or an error, and the request mustn't go ahead. The claim is nil when
+// there's no key.
+func (s *Server) claimIdempotencyKey(w http.ResponseWriter, r *http.Request, key *string, payload any, resource string) (claim *idempotencyClaim, ok bool) {
+	if key == nil {
+		return nil, true
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	hash, err := requestHash(r, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, resource)
+		return nil, false
+	}
+
+	stored, err := s.idempotency.Claim(r.Context(), organisationID, *key, hash)
+
+	if err != nil {
+		s.handleError(w, r, err, resource)
+		return nil, false
+	}
+
+	if stored != nil {
+		s.log(r).Debug("Replaying response for idempotency key", "status", stored.Status)
+
+		if stored.ETag != "" {
+			w.Header().Set("ETag", stored.ETag)
+		}
+
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(stored.Status)
+		w.Write(stored.Body)
+
+		return nil, false
+	}
+
+	return &idempotencyClaim{organisationID: organisationID, key: *key}, true
+}
+
+// releaseIdempotencyKey gives up the key of a request that failed, so it can be retried
+func (s *Server) releaseIdempotencyKey(r *http.Request, claim *idempotencyClaim) {
+	if claim == nil {
+		return
+	}
+
+	// the request may have failed because it was cancelled, which mustn't stop the
+	// key being released
+	ctx := context.WithoutCancel(r.Context())
+
+	if err := s.idempotency.Release(ctx, claim.organisationID, claim.key); err != nil {
+		s.log(r).Error("unable to release idempotency key", "error", err)
+	}
+}
+
+// createRecord runs a create. When the request has claimed an Idempotency-Key the
+// response is stored in the transaction that creates the record, so a record is
+// never created without the response a retry replays, and the key is released when
+// the create fails. It returns the record and its encoded response.
+func createRecord[T any](s *Server, r *http.Request, claim *idempotencyClaim, tag func(T) string, create func(storage.RecordRepositories) (T, error)) (T, []byte, error) {
+	var record T
+	var body []byte
+
+	run := func(repositories storage.RecordRepositories) error {
+		var err error
+		record, err = create(repositories)
+
+		if err != nil {
+			return err
+		}
+
+		body, err = json.Marshal(record)
+
+		if err != nil {
+			return err
+		}
+
+		// the same as json.Encoder, which the other responses are written with
+		body = append(body, '\n')
+
+		if claim == nil {
+			return nil
+		}
+
+		response := storage.StoredResponse{Status: http.StatusCreated, Body: body, ETag: tag(record)}
+
+		return repositories.Idempotency.Complete(r.Context(), claim.organisationID, claim.key, response)
+	}
+
+	if claim == nil {
+		err := run(s.repositories())
+		return record, body, err
+	}
+
+	err := s.transactor.InTx(r.Context(), run)
+
+	if err != nil {
+		s.releaseIdempotencyKey(r, claim)
+	}
+
+	return record, body, err
+}
+
+// writeCreated writes the encoded response for a created record
+func writeCreated(w http.ResponseWriter, tag string, body []byte) {
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+// requestHash identifies a request by its method, path and decoded body, so a retry
+// matches however its JSON is formatted
+func requestHash(r *http.Request, payload any) (string, error) {
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
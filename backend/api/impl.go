@@ -1,21 +1,20 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"log/slog"
+	"maps"
 	"math"
 	"net/http"
-	"strings"
+	"slices"
+	"time"
 
+	"github.com/davidtaing/property-management/internal/logging"
+	"github.com/davidtaing/property-management/internal/members"
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
 	"github.com/davidtaing/property-management/internal/types"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/pgtype"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
@@ -23,1372 +22,916 @@ import (
 var _ ServerInterface = (*Server)(nil)
 
 type Server struct {
-	dbpool *pgxpool.Pool
-	logger *slog.Logger
+	landlords           storage.LandlordRepository
+	properties          storage.PropertyRepository
+	tenants             storage.TenantRepository
+	organisations       storage.OrganisationRepository
+	portalUsers         storage.PortalUserRepository
+	maintenanceRequests storage.MaintenanceRequestRepository
+	payments            storage.PaymentRepository
+	search              storage.SearchRepository
+	idempotency         storage.IdempotencyRepository
+	transactor          storage.Transactor
+	members             members.Directory
+	logger              *slog.Logger
 }
 
-func NewServer(dbpool *pgxpool.Pool, logger *slog.Logger) *Server {
+func NewServer(repositories storage.Repositories, directory members.Directory, logger *slog.Logger) *Server {
 	return &Server{
-		dbpool: dbpool,
-		logger: logger,
+		landlords:           repositories.Landlords,
+		properties:          repositories.Properties,
+		tenants:             repositories.Tenants,
+		organisations:       repositories.Organisations,
+		portalUsers:         repositories.PortalUsers,
+		maintenanceRequests: repositories.MaintenanceRequests,
+		payments:            repositories.Payments,
+		search:              repositories.Search,
+		idempotency:         repositories.Idempotency,
+		transactor:          repositories.Transactor,
+		members:             directory,
+		logger:              logger,
 	}
 }
 
-func (s *Server) LandlordsList(w http.ResponseWriter, r *http.Request, params LandlordsListParams) {
-	landlords := []Landlord{}
+// log returns the request's logger, which is tagged with the request ID, organisation
+// and user
+func (s *Server) log(r *http.Request) *slog.Logger {
+	return logging.FromContext(r.Context(), s.logger)
+}
 
-	limit, page, offset := handlePaginationParams(params)
+// repositories returns the record repositories that write outside of a transaction
+func (s *Server) repositories() storage.RecordRepositories {
+	return storage.RecordRepositories{
+		Landlords:   s.landlords,
+		Properties:  s.properties,
+		Tenants:     s.tenants,
+		Idempotency: s.idempotency,
+	}
+}
 
-	organisationID := r.Context().Value(types.OrgIDKey)
+func (s *Server) LandlordsList(w http.ResponseWriter, r *http.Request, params models.LandlordsListParams) {
+	page, pageNumber, err := handlePaginationParams(params)
 
-	conditions := map[string]interface{}{
-		"name":            params.Name,
-		"archived_only":   params.ArchivedOnly,
-		"organisation_id": organisationID,
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
 	}
 
-	whereClause, queryParams, paramCount := buildWhereClause(conditions)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
-	// Get total count
-	countSQL := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM landlords
-		%s`, whereClause)
+	filter := storage.LandlordFilter{
+		Name:         params.Name,
+		ArchivedOnly: params.ArchivedOnly,
+		Suburb:       stringSet(params.Suburb),
+		State:        stringSet(params.State),
+		Sort:         listParam(params.Sort),
+		Fields:       listParam(params.Fields),
+		Page:         page,
+	}
+
+	version, err := s.landlords.ListVersion(r.Context(), organisationID, filter)
 
-	var total int
-	err := s.dbpool.QueryRow(context.Background(), countSQL, queryParams...).Scan(&total)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Get paginated results
-	queryParams = append(queryParams, limit, offset)
-	listSQL := fmt.Sprintf(`
-		SELECT 
-			id, 
-			name, 
-			email, 
-			mobile, 
-			phone, 
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			is_archived,
-			created_at,
-			updated_at
-		FROM landlords
-		%s
-		ORDER BY name
-		LIMIT $%d 
-		OFFSET $%d`, whereClause, paramCount, paramCount+1)
-
-	rows, err := s.dbpool.Query(context.Background(), listSQL, queryParams...)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var landlord Landlord
-
-		err := rows.Scan(
-			&landlord.Id,
-			&landlord.Name,
-			&landlord.Email,
-			&landlord.Mobile,
-			&landlord.Phone,
-			&landlord.AddressLine1,
-			&landlord.AddressLine2,
-			&landlord.Suburb,
-			&landlord.Postcode,
-			&landlord.State,
-			&landlord.Country,
-			&landlord.IsArchived,
-			&landlord.CreatedAt,
-			&landlord.UpdatedAt,
-		)
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
 
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	v := listValidators(version, listShape{page: page, sort: filter.Sort, fields: filter.Fields})
 
-		landlords = append(landlords, landlord)
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
+		return
 	}
 
-	if err := rows.Err(); err != nil {
-		apiError := handleLandlordErrors(err)
+	landlords, info, err := s.landlords.List(r.Context(), organisationID, filter)
 
-		w.WriteHeader(int(apiError.Code))
-		json.NewEncoder(w).Encode(apiError)
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
 		return
 	}
 
-	resp := LandlordList{
-		Items: landlords,
-		Pagination: PaginatedMetadata{
-			Total:       int32(total),
-			Count:       int32(len(landlords)),
-			PerPage:     int32(limit),
-			CurrentPage: int32(page),
-			TotalPages:  int32(math.Ceil(float64(total) / float64(limit))),
-		},
+	pagination, cursors := paginationMetadata(page, pageNumber, len(landlords), info)
+
+	resp := models.LandlordList{
+		Items:      landlords,
+		Pagination: pagination,
+		Cursors:    cursors,
 	}
 
+	body, err := sparseList(resp, listParam(params.Fields))
+
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	v.set(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(resp)
+	err = json.NewEncoder(w).Encode(body)
 
 	if err != nil {
-		s.logger.Error("error encoding response", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.log(r).Error("error encoding response", "error", err)
 		return
 	}
 
-	s.logger.Debug("Landlords List Response", "response", resp)
+	s.log(r).Debug("Landlords List Response", "response", resp)
 }
 
-func (s *Server) LandlordsCreate(w http.ResponseWriter, r *http.Request) {
-	var payload CreateLandlord
+func (s *Server) LandlordsCreate(w http.ResponseWriter, r *http.Request, params models.LandlordsCreateParams) {
+	var payload models.CreateLandlord
 	err := json.NewDecoder(r.Body).Decode(&payload)
 
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
+		writeError(w, invalidRequestError(err))
 		return
 	}
 
-	id, err := uuid.NewV7()
+	claim, ok := s.claimIdempotencyKey(w, r, params.IdempotencyKey, payload, landlordResource)
 
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
-		return
-	}
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	sql := `
-		INSERT INTO landlords (
-			id,
-			name,
-			email,
-			mobile,
-			phone,
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			organisation_id
-		) VALUES (
-			$1,
-			$2,
-			$3,
-			$4,
-			$5,
-			$6,
-			$7,
-			$8,
-			$9,
-			$10,
-			$11,
-			$12
-		) RETURNING 
-		 	id,
-			name,
-			email,
-			mobile,
-			phone,
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			is_archived,
-			created_at,
-			updated_at
-		`
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id.String(),
-		payload.Name,
-		payload.Email,
-		payload.Mobile,
-		payload.Phone,
-		payload.AddressLine1,
-		payload.AddressLine2,
-		payload.Suburb,
-		payload.Postcode,
-		payload.State,
-		payload.Country,
-		organisationID,
-	)
-
-	var createdLandlord Landlord
-
-	err = row.Scan(
-		&createdLandlord.Id,
-		&createdLandlord.Name,
-		&createdLandlord.Email,
-		&createdLandlord.Mobile,
-		&createdLandlord.Phone,
-		&createdLandlord.AddressLine1,
-		&createdLandlord.AddressLine2,
-		&createdLandlord.Suburb,
-		&createdLandlord.Postcode,
-		&createdLandlord.State,
-		&createdLandlord.Country,
-		&createdLandlord.IsArchived,
-		&createdLandlord.CreatedAt,
-		&createdLandlord.UpdatedAt,
-	)
+	if !ok {
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	tag := func(landlord models.Landlord) string { return etag(landlord.UpdatedAt) }
+
+	createdLandlord, body, err := createRecord(s, r, claim, tag, func(repositories storage.RecordRepositories) (models.Landlord, error) {
+		return repositories.Landlords.Create(r.Context(), organisationID, payload)
+	})
 
 	if err != nil {
-		apiError := handleLandlordErrors(err)
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	s.log(r).Debug("Landlord Created", "landlord", createdLandlord)
+
+	writeCreated(w, tag(createdLandlord), body)
+}
+
+func (s *Server) LandlordsArchive(w http.ResponseWriter, r *http.Request, id string, params models.LandlordsArchiveParams) {
+	if err := validateID(id, landlordResource); err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	versions, ok := ifMatch(params.IfMatch)
 
-		w.WriteHeader(int(apiError.Code))
-		json.NewEncoder(w).Encode(apiError)
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, landlordResource)
 		return
 	}
 
-	s.logger.Debug("Landlord Created", "landlord", createdLandlord)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
+	archivedLandlord, err := s.landlords.Archive(r.Context(), organisationID, id, versions)
+
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	s.log(r).Debug("Landlord Archived", "landlord", archivedLandlord)
+
+	w.Header().Set("ETag", etag(archivedLandlord.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(createdLandlord)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(archivedLandlord)
 }
 
-func (s *Server) LandlordsArchive(w http.ResponseWriter, r *http.Request, id string) {
-	var archivedLandlord Landlord
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	sql := `
-		UPDATE landlords 
-		SET 
-			is_archived = NOW(),  
-			updated_at = NOW()
-		WHERE 
-			id = $1 
-			AND organisation_id = $2
-		RETURNING 
-			id, 
-			name, 
-			email, 
-			mobile, 
-			phone, 
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			is_archived,
-			created_at,
-			updated_at
-	`
-
-	err := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	).Scan(
-		&archivedLandlord.Id,
-		&archivedLandlord.Name,
-		&archivedLandlord.Email,
-		&archivedLandlord.Mobile,
-		&archivedLandlord.Phone,
-		&archivedLandlord.AddressLine1,
-		&archivedLandlord.AddressLine2,
-		&archivedLandlord.Suburb,
-		&archivedLandlord.Postcode,
-		&archivedLandlord.State,
-		&archivedLandlord.Country,
-		&archivedLandlord.IsArchived,
-		&archivedLandlord.CreatedAt,
-		&archivedLandlord.UpdatedAt,
-	)
+func (s *Server) LandlordsGet(w http.ResponseWriter, r *http.Request, id string, params models.LandlordsGetParams) {
+	if err := validateID(id, landlordResource); err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	landlord, err := s.landlords.Get(r.Context(), organisationID, id)
 
 	if err != nil {
-		apiError := handleLandlordErrors(err)
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
 
-		w.WriteHeader(int(apiError.Code))
-		json.NewEncoder(w).Encode(apiError)
+	fields := listParam(params.Fields)
+	v := recordValidators(landlord.UpdatedAt).withFields(fields)
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
 		return
 	}
 
-	s.logger.Debug("Landlord Archived", "landlord", archivedLandlord)
+	s.log(r).Debug("Landlord Retrieved", "landlord", landlord)
+
+	body, err := sparseRecord(landlord, fields)
+
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
 
+	v.set(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(archivedLandlord)
+	json.NewEncoder(w).Encode(body)
 }
 
-func (s *Server) LandlordsGet(w http.ResponseWriter, r *http.Request, id string) {
-	var landlord Landlord
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	sql := `
-		SELECT 
-			id, 
-			name, 
-			email, 
-			mobile, 
-			phone, 
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			is_archived,
-			created_at,
-			updated_at
-		FROM landlords 
-		WHERE 
-			id = $1 
-			AND organisation_id = $2
-	`
-
-	err := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	).Scan(
-		&landlord.Id,
-		&landlord.Name,
-		&landlord.Email,
-		&landlord.Mobile,
-		&landlord.Phone,
-		&landlord.AddressLine1,
-		&landlord.AddressLine2,
-		&landlord.Suburb,
-		&landlord.Postcode,
-		&landlord.State,
-		&landlord.Country,
-		&landlord.IsArchived,
-		&landlord.CreatedAt,
-		&landlord.UpdatedAt,
-	)
+// LandlordsListProperties lists a landlord's properties, the same as filtering the
+// properties list by landlord_id but with a 404 when the landlord doesn't exist
+func (s *Server) LandlordsListProperties(w http.ResponseWriter, r *http.Request, id string, params models.LandlordsListPropertiesParams) {
+	if err := validateID(id, landlordResource); err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
+	page, pageNumber, err := handlePaginationParams(params)
 
 	if err != nil {
-		apiError := handleLandlordErrors(err)
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
-		w.WriteHeader(int(apiError.Code))
-		json.NewEncoder(w).Encode(apiError)
+	if _, err := s.landlords.Get(r.Context(), organisationID, id); err != nil {
+		s.handleError(w, r, err, landlordResource)
 		return
 	}
 
-	s.logger.Debug("Landlord Retrieved", "landlord", landlord)
+	filter := storage.PropertyFilter{
+		ArchivedOnly: params.ArchivedOnly,
+		LandlordID:   storage.Set{canonicalID(id)},
+		Sort:         listParam(params.Sort),
+		Page:         page,
+	}
 
+	version, err := s.properties.ListVersion(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	v := listValidators(version, listShape{page: page, sort: filter.Sort})
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
+		return
+	}
+
+	properties, info, err := s.properties.List(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	pagination, cursors := paginationMetadata(page, pageNumber, len(properties), info)
+
+	resp := models.PropertyList{
+		Items:      properties,
+		Pagination: pagination,
+		Cursors:    cursors,
+	}
+
+	s.log(r).Debug("Landlord Properties List Response", "response", resp)
+
+	v.set(w)
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(landlord)
+	json.NewEncoder(w).Encode(resp)
 }
 
-func (s *Server) LandlordsUpdate(w http.ResponseWriter, r *http.Request, id string) {
-	var payload UpdateLandlord
+func (s *Server) LandlordsUpdate(w http.ResponseWriter, r *http.Request, id string, params models.LandlordsUpdateParams) {
+	if err := validateID(id, landlordResource); err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	var payload models.UpdateLandlord
 	err := json.NewDecoder(r.Body).Decode(&payload)
 
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
-		return
-	}
-
-	setClause, values, paramCount := buildLandlordUpdateSetClause(payload)
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-	values = append(values, id, organisationID)
-
-	sql := fmt.Sprintf(`
-		UPDATE landlords 
-		%s
-		WHERE 
-			id = $%d
-			AND organisation_id = $%d
-		RETURNING id,
-			name,
-			email,
-			mobile,
-			phone,
-			address_line_1,
-			address_line_2,
-			suburb,
-			postcode,
-			state,
-			country,
-			is_archived,
-			created_at,
-			updated_at
-	`, setClause, paramCount+1, paramCount+2)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		values...,
-	)
-
-	var updatedLandlord Landlord
-
-	err = row.Scan(
-		&updatedLandlord.Id,
-		&updatedLandlord.Name,
-		&updatedLandlord.Email,
-		&updatedLandlord.Mobile,
-		&updatedLandlord.Phone,
-		&updatedLandlord.AddressLine1,
-		&updatedLandlord.AddressLine2,
-		&updatedLandlord.Suburb,
-		&updatedLandlord.Postcode,
-		&updatedLandlord.State,
-		&updatedLandlord.Country,
-		&updatedLandlord.IsArchived,
-		&updatedLandlord.CreatedAt,
-		&updatedLandlord.UpdatedAt,
-	)
+		writeError(w, invalidRequestError(err))
+		return
+	}
 
-	if err != nil {
-		apiError := handleLandlordErrors(err)
+	versions, ok := ifMatch(params.IfMatch)
 
-		w.WriteHeader(int(apiError.Code))
-		json.NewEncoder(w).Encode(apiError)
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, landlordResource)
 		return
 	}
 
-	s.logger.Debug("Landlord Updated", "landlord", updatedLandlord)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
+	updatedLandlord, err := s.landlords.Update(r.Context(), organisationID, id, payload, versions)
+
+	if err != nil {
+		s.handleError(w, r, err, landlordResource)
+		return
+	}
+
+	s.log(r).Debug("Landlord Updated", "landlord", updatedLandlord)
+
+	w.Header().Set("ETag", etag(updatedLandlord.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(updatedLandlord)
 }
 
-func (s *Server) PropertiesList(w http.ResponseWriter, r *http.Request, params PropertiesListParams) {
-	properties := []Property{}
+func (s *Server) PropertiesList(w http.ResponseWriter, r *http.Request, params models.PropertiesListParams) {
+	page, pageNumber, err := handlePaginationParams(params)
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
 
-	limit, page, offset := handlePaginationParams(params)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
-	organisationID := r.Context().Value(types.OrgIDKey)
+	fields := listParam(params.Fields)
+	foreignKeys := []string{}
 
-	conditions := map[string]interface{}{
-		"full_address":    params.Address,
-		"archived_only":   params.ArchivedOnly,
-		"organisation_id": organisationID,
+	// the landlord is found by the property's landlord_id, so it has to be selected
+	if params.Expand != nil && slices.Contains(*params.Expand, models.PropertyExpandLandlord) {
+		foreignKeys = append(foreignKeys, "landlord_id")
 	}
 
-	whereClause, queryParams, paramCount := buildWhereClause(conditions)
-
-	sql := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM properties
-		%s
-	`, whereClause)
+	filter := storage.PropertyFilter{
+		Address:          params.Address,
+		ArchivedOnly:     params.ArchivedOnly,
+		AssignedTo:       resolveAssignee(r, params.AssignedTo),
+		LandlordID:       idSet(params.LandlordId),
+		Suburb:           stringSet(params.Suburb),
+		State:            stringSet(params.State),
+		Postcode:         stringSet(params.Postcode),
+		ManagementGained: dateRange(params.ManagementGainedFrom, params.ManagementGainedTo),
+		ManagementFee:    storage.Range[float64]{From: params.ManagementFeeMin, To: params.ManagementFeeMax},
+		Sort:             listParam(params.Sort),
+		Fields:           queryFields(fields, foreignKeys...),
+		Page:             page,
+	}
 
-	var total int
+	version, err := s.properties.ListVersion(r.Context(), organisationID, filter)
 
-	err := s.dbpool.QueryRow(context.Background(), sql, queryParams...).Scan(&total)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	queryParams = append(queryParams, limit, offset)
-
-	sql = fmt.Sprintf(`
-		SELECT 
-			id,
-			street_number,
-			street_name,
-			suburb,
-			state,
-			postcode,
-			country,
-			landlord_id,
-			management_fee,
-			management_gained,
-			management_lost,
-			is_archived,
-			created_at,
-			updated_at
-		FROM properties
-		%s
-		ORDER BY street_name, street_number
-		LIMIT $%d
-		OFFSET $%d
-	`, whereClause, paramCount, paramCount+1)
-
-	rows, err := s.dbpool.Query(context.Background(), sql, queryParams...)
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	expanded, err := s.propertyExpandVersions(r.Context(), organisationID, params.Expand)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.handleError(w, r, err, propertyResource)
 		return
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		property, err := scanProperty(rows)
+	v := listValidators(version, listShape{page: page, sort: filter.Sort, fields: fields, expand: expandNames(params.Expand)}, expanded...)
 
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
+		return
+	}
+
+	properties, info, err := s.properties.List(r.Context(), organisationID, filter)
 
-		properties = append(properties, property)
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
 	}
 
-	if err := rows.Err(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := s.expandProperties(r.Context(), organisationID, properties, params.Expand); err != nil {
+		s.handleError(w, r, err, propertyResource)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	pagination, cursors := paginationMetadata(page, pageNumber, len(properties), info)
 
-	resp := PropertyList{
-		Items: properties,
-		Pagination: PaginatedMetadata{
-			Total:       int32(total),
-			Count:       int32(len(properties)),
-			PerPage:     int32(limit),
-			CurrentPage: int32(page),
-			TotalPages:  int32(math.Ceil(float64(total) / float64(limit))),
-		},
+	resp := models.PropertyList{
+		Items:      properties,
+		Pagination: pagination,
+		Cursors:    cursors,
 	}
 
-	s.logger.Debug("Properties List Response", "response", resp)
+	s.log(r).Debug("Properties List Response", "response", resp)
 
-	json.NewEncoder(w).Encode(resp)
+	body, err := sparseList(resp, fields, "landlord")
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	v.set(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
 }
 
-func (s *Server) PropertiesCreate(w http.ResponseWriter, r *http.Request) {
-	var payload CreateProperty
+func (s *Server) PropertiesCreate(w http.ResponseWriter, r *http.Request, params models.PropertiesCreateParams) {
+	var payload models.CreateProperty
 	err := json.NewDecoder(r.Body).Decode(&payload)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	if err := s.assigneeCheck(r)(r.Context(), payload.AssignedTo); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	claim, ok := s.claimIdempotencyKey(w, r, params.IdempotencyKey, payload, propertyResource)
+
+	if !ok {
 		return
 	}
 
-	id, err := uuid.NewV7()
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	tag := func(property models.Property) string { return etag(property.UpdatedAt) }
+
+	createdProperty, body, err := createRecord(s, r, claim, tag, func(repositories storage.RecordRepositories) (models.Property, error) {
+		return repositories.Properties.Create(r.Context(), organisationID, payload)
+	})
 
 	if err != nil {
-		s.logger.Info("Failed to generate UUID", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
-		return
-	}
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	sql := `
-		INSERT INTO properties (
-			id,
-			street_number,
-			street_name,
-			suburb,
-			state,	
-			postcode,
-			country,
-			landlord_id,
-			management_fee,
-			management_gained,
-			organisation_id
-		) VALUES (
-			$1,
-			$2,
-			$3,
-			$4,
-			$5,
-			$6,
-			$7,
-			$8,
-			$9,
-			$10,
-			$11
-		) RETURNING 
-			id, 
-			street_number, 
-			street_name, 
-			suburb, 
-			state, 
-			postcode, 
-			country,
-			landlord_id, 
-			management_fee, 
-			management_gained,
-			management_lost,
-			is_archived,
-			created_at,
-			updated_at
-	`
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id.String(),
-		payload.StreetNumber,
-		payload.StreetName,
-		payload.Suburb,
-		payload.State,
-		payload.Postcode,
-		payload.Country,
-		payload.LandlordId,
-		payload.ManagementFee,
-		payload.ManagementGained,
-		organisationID,
-	)
-
-	createdProperty, err := scanProperty(row)
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	s.log(r).Debug("Property Created", "property", createdProperty)
+
+	writeCreated(w, tag(createdProperty), body)
+}
+
+func (s *Server) PropertiesArchive(w http.ResponseWriter, r *http.Request, id string, params models.PropertiesArchiveParams) {
+	if err := validateID(id, propertyResource); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	versions, ok := ifMatch(params.IfMatch)
+
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, propertyResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	archivedProperty, err := s.properties.Archive(r.Context(), organisationID, id, versions)
 
 	if err != nil {
-		s.logger.Info("Failed to create property", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, propertyResource)
 		return
 	}
 
-	s.logger.Debug("Property Created", "property", createdProperty)
+	s.log(r).Debug("Property Archived", "property", archivedProperty)
 
+	w.Header().Set("ETag", etag(archivedProperty.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(createdProperty)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(archivedProperty)
 }
 
-func (s *Server) PropertiesArchive(w http.ResponseWriter, r *http.Request, id string) {
-	var archivedProperty Property
-
-	sql := `
-		UPDATE properties 
-		SET 
-			is_archived = NOW(),
-			updated_at = NOW()
-		WHERE 
-			id = $1
-			AND organisation_id = $2
-		RETURNING 
-			id,
-			street_number,
-			street_name,
-			suburb,
-			state,
-			postcode,
-			country,
-			landlord_id,
-			management_fee,
-			management_gained,
-			management_lost,
-			is_archived,
-			created_at,
-			updated_at
-	`
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	)
-
-	archivedProperty, err := scanProperty(row)
+func (s *Server) PropertiesGet(w http.ResponseWriter, r *http.Request, id string, params models.PropertiesGetParams) {
+	if err := validateID(id, propertyResource); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	property, err := s.properties.Get(r.Context(), organisationID, id)
 
 	if err != nil {
-		s.logger.Info("Failed to archive property", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, propertyResource)
 		return
 	}
 
-	s.logger.Debug("Property Archived", "property", archivedProperty)
+	properties := []models.Property{property}
 
+	if err := s.expandProperties(r.Context(), organisationID, properties, params.Expand); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	property = properties[0]
+
+	fields := listParam(params.Fields)
+	v := propertyValidators(property).withFields(fields)
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
+		return
+	}
+
+	s.log(r).Debug("Property Retrieved", "property", property)
+
+	body, err := sparseRecord(property, fields, "landlord")
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	v.set(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(archivedProperty)
+	json.NewEncoder(w).Encode(body)
 }
 
-func (s *Server) PropertiesGet(w http.ResponseWriter, r *http.Request, id string) {
-	var property Property
-
-	sql := `
-		SELECT 
-			id,
-			street_number,
-			street_name,
-			suburb,
-			state,
-			postcode,
-			country,
-			landlord_id,
-			management_fee,
-			management_gained,
-			management_lost,
-			is_archived,
-			created_at,
-			updated_at
-		FROM properties 
-		WHERE 
-			id = $1
-			AND organisation_id = $2
-	`
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	)
-
-	property, err := scanProperty(row)
+// PropertiesListTenants lists a property's tenants, the same as filtering the tenants
+// list by property_id but with a 404 when the property doesn't exist
+func (s *Server) PropertiesListTenants(w http.ResponseWriter, r *http.Request, id string, params models.PropertiesListTenantsParams) {
+	if err := validateID(id, propertyResource); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	page, pageNumber, err := handlePaginationParams(params)
 
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, tenantResource)
 		return
 	}
 
-	s.logger.Debug("Property Retrieved", "property", property)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	if _, err := s.properties.Get(r.Context(), organisationID, id); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
 
+	filter := storage.TenantFilter{
+		ArchivedOnly: params.ArchivedOnly,
+		PropertyID:   storage.Set{canonicalID(id)},
+		Sort:         listParam(params.Sort),
+		Page:         page,
+	}
+
+	version, err := s.tenants.ListVersion(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	v := listValidators(version, listShape{page: page, sort: filter.Sort})
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
+		return
+	}
+
+	tenants, info, err := s.tenants.List(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	pagination, cursors := paginationMetadata(page, pageNumber, len(tenants), info)
+
+	resp := models.TenantList{
+		Items:      tenants,
+		Pagination: pagination,
+		Cursors:    cursors,
+	}
+
+	s.log(r).Debug("Property Tenants List Response", "response", resp)
+
+	v.set(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(property)
+	json.NewEncoder(w).Encode(resp)
 }
 
-func (s *Server) PropertiesUpdate(w http.ResponseWriter, r *http.Request, id string) {
-	var payload UpdateProperty
+func (s *Server) PropertiesUpdate(w http.ResponseWriter, r *http.Request, id string, params models.PropertiesUpdateParams) {
+	if err := validateID(id, propertyResource); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	var payload models.UpdateProperty
 	err := json.NewDecoder(r.Body).Decode(&payload)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
-		return
-	}
-
-	setClause, values, paramCount := buildPropertyUpdateSetClause(payload)
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-	values = append(values, id, organisationID)
-
-	sql := fmt.Sprintf(`
-		UPDATE properties 
-		%s
-		WHERE 
-			id = $%d
-			AND organisation_id = $%d
-		RETURNING 
-			id, 
-			street_number, 
-			street_name, 
-			suburb, 
-			state, 
-			postcode, 
-			country,
-			landlord_id, 
-			management_fee, 
-			management_gained,
-			management_lost,
-			is_archived,
-			created_at,
-			updated_at
-	`, setClause, paramCount+1, paramCount+2)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		values...,
-	)
-
-	updatedProperty, err := scanProperty(row)
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	versions, ok := ifMatch(params.IfMatch)
+
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, propertyResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	assignee, _ := payload.AssignedTo.Get()
+
+	if err := s.assigneeCheck(r)(r.Context(), &assignee); err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	updatedProperty, err := s.properties.Update(r.Context(), organisationID, id, payload, versions)
 
 	if err != nil {
-		s.logger.Info("Failed to update property", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, propertyResource)
 		return
 	}
 
-	s.logger.Debug("Property Updated", "property", updatedProperty)
+	s.log(r).Debug("Property Updated", "property", updatedProperty)
 
+	w.Header().Set("ETag", etag(updatedProperty.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(updatedProperty)
 }
 
-func (s *Server) TenantsList(w http.ResponseWriter, r *http.Request, params TenantsListParams) {
-	tenants := []Tenant{}
+func (s *Server) TenantsList(w http.ResponseWriter, r *http.Request, params models.TenantsListParams) {
+	page, pageNumber, err := handlePaginationParams(params)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
 
-	limit, page, offset := handlePaginationParams(params)
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
-	organisationID := r.Context().Value(types.OrgIDKey)
+	fields := listParam(params.Fields)
+	foreignKeys := []string{}
 
-	conditions := map[string]interface{}{
-		"name":            params.Name,
-		"archived_only":   params.ArchivedOnly,
-		"organisation_id": organisationID,
+	// the property is found by the tenant's property_id, so it has to be selected
+	if params.Expand != nil && len(*params.Expand) > 0 {
+		foreignKeys = append(foreignKeys, "property_id")
 	}
 
-	whereClause, queryParams, paramCount := buildWhereClause(conditions)
-
-	sql := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM tenants 
-		%s
-	`, whereClause)
+	filter := storage.TenantFilter{
+		Name:               params.Name,
+		ArchivedOnly:       params.ArchivedOnly,
+		PropertyAssignedTo: resolveAssignee(r, params.AssignedTo),
+		PropertyID:         idSet(params.PropertyId),
+		Frequency:          stringSet(params.Frequency),
+		PaidTo:             dateRange(params.PaidToFrom, params.PaidToTo),
+		EndDate:            dateRange(params.EndDateFrom, params.EndDateTo),
+		RentalAmount:       storage.Range[float64]{From: params.RentalAmountMin, To: params.RentalAmountMax},
+		Sort:               listParam(params.Sort),
+		Fields:             queryFields(fields, foreignKeys...),
+		Page:               page,
+	}
 
-	var total int
+	version, err := s.tenants.ListVersion(r.Context(), organisationID, filter)
 
-	err := s.dbpool.QueryRow(context.Background(), sql, queryParams...).Scan(&total)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
-		return
-	}
-
-	queryParams = append(queryParams, limit, offset)
-
-	sql = fmt.Sprintf(`
-		SELECT 
-			id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			termination_date,
-			termination_reason,
-			vacate_date,
-			is_archived,
-			property_id,
-			created_at,
-			updated_at
-		FROM tenants
-		%s
-		LIMIT $%d
-		OFFSET $%d
-	`, whereClause, paramCount, paramCount+1)
-
-	rows, err := s.dbpool.Query(context.Background(), sql, queryParams...)
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	expanded, err := s.tenantExpandVersions(r.Context(), organisationID, params.Expand)
+
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.handleError(w, r, err, tenantResource)
 		return
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		tenant, err := scanTenant(rows)
+	// the assignee is the property's, so reassigning a property changes the list
+	// without changing any tenant
+	if filter.PropertyAssignedTo != nil {
+		properties, err := s.properties.ListVersion(r.Context(), organisationID, storage.PropertyFilter{})
+
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			json.NewEncoder(w).Encode(Error{
-				Code:    http.StatusInternalServerError,
-				Message: "Internal server error",
-			})
+			s.handleError(w, r, err, tenantResource)
 			return
 		}
-		tenants = append(tenants, tenant)
+
+		expanded = append(expanded, properties)
 	}
 
-	if err := rows.Err(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+	v := listValidators(version, listShape{page: page, sort: filter.Sort, fields: fields, expand: expandNames(params.Expand)}, expanded...)
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	tenants, info, err := s.tenants.List(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
 
-	resp := TenantList{
-		Items: tenants,
-		Pagination: PaginatedMetadata{
-			Total:       int32(total),
-			Count:       int32(len(tenants)),
-			PerPage:     int32(limit),
-			CurrentPage: int32(page),
-			TotalPages:  int32(math.Ceil(float64(total) / float64(limit))),
-		},
+	if err := s.expandTenants(r.Context(), organisationID, tenants, params.Expand); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
 	}
 
-	s.logger.Debug("Tenants List Response", "response", resp)
+	pagination, cursors := paginationMetadata(page, pageNumber, len(tenants), info)
 
-	json.NewEncoder(w).Encode(resp)
+	resp := models.TenantList{
+		Items:      tenants,
+		Pagination: pagination,
+		Cursors:    cursors,
+	}
+
+	s.log(r).Debug("Tenants List Response", "response", resp)
+
+	body, err := sparseList(resp, fields, "property")
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	v.set(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(body)
 }
 
-func (s *Server) TenantsCreate(w http.ResponseWriter, r *http.Request) {
-	var payload CreateTenant
+func (s *Server) TenantsCreate(w http.ResponseWriter, r *http.Request, params models.TenantsCreateParams) {
+	var payload models.CreateTenant
 	err := json.NewDecoder(r.Body).Decode(&payload)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, invalidRequestError(err))
 		return
 	}
 
-	id, err := uuid.NewV7()
+	claim, ok := s.claimIdempotencyKey(w, r, params.IdempotencyKey, payload, tenantResource)
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	sql := `
-		INSERT INTO tenants (
-			id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			property_id,
-			organisation_id
-		)
-		VALUES (
-			$1,
-			$2,
-			$3,
-			$4,
-			$5,
-			$6,
-			$7,
-			$8,
-			$9,
-			$10,
-			$11,
-			$12,
-			$13,
-			$14
-		) RETURNING 
-		 	id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			termination_date,
-			termination_reason,
-			vacate_date,
-			is_archived,
-			property_id,
-			created_at,
-			updated_at
-	`
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id.String(),
-		payload.Name,
-		payload.Email,
-		payload.Mobile,
-		payload.Phone,
-		payload.PaidTo,
-		payload.PaidTo,
-		payload.RentalAmount,
-		payload.Frequency,
-		payload.OriginalStartDate,
-		payload.StartDate,
-		payload.EndDate,
-		payload.PropertyId,
-		organisationID,
-	)
-
-	createdTenant, err := scanTenant(row)
+	if !ok {
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	tag := func(tenant models.Tenant) string { return etag(tenant.UpdatedAt) }
+
+	createdTenant, body, err := createRecord(s, r, claim, tag, func(repositories storage.RecordRepositories) (models.Tenant, error) {
+		return repositories.Tenants.Create(r.Context(), organisationID, payload)
+	})
 
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, tenantResource)
 		return
 	}
 
-	s.logger.Debug("Tenant Created", "tenant", createdTenant)
+	s.log(r).Debug("Tenant Created", "tenant", createdTenant)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(createdTenant)
+	writeCreated(w, tag(createdTenant), body)
 }
 
-func (s *Server) TenantsArchive(w http.ResponseWriter, r *http.Request, id string) {
-	var archivedTenant Tenant
-
-	sql := `
-		UPDATE tenants 
-		SET 
-			is_archived = NOW(),
-			updated_at = NOW()
-		WHERE 
-			id = $1
-			AND organisation_id = $2
-		RETURNING 
-			id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			termination_date,
-			termination_reason,
-			vacate_date,
-			is_archived,
-			property_id,
-			created_at,
-			updated_at
-	`
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	)
-
-	archivedTenant, err := scanTenant(row)
+func (s *Server) TenantsArchive(w http.ResponseWriter, r *http.Request, id string, params models.TenantsArchiveParams) {
+	if err := validateID(id, tenantResource); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	versions, ok := ifMatch(params.IfMatch)
+
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, tenantResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	archivedTenant, err := s.tenants.Archive(r.Context(), organisationID, id, versions)
 
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+		s.handleError(w, r, err, tenantResource)
 		return
 	}
 
-	s.logger.Debug("Tenant Archived", "tenant", archivedTenant)
+	s.log(r).Debug("Tenant Archived", "tenant", archivedTenant)
 
+	w.Header().Set("ETag", etag(archivedTenant.UpdatedAt))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(archivedTenant)
 }
 
-func (s *Server) TenantsGet(w http.ResponseWriter, r *http.Request, id string) {
-	var tenant Tenant
-
-	sql := `
-		SELECT id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			termination_date,
-			termination_reason,
-			vacate_date,
-			is_archived,
-			property_id,
-			created_at,
-			updated_at
-		FROM tenants 
-		WHERE 
-			id = $1
-			AND organisation_id = $2
-	`
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		id,
-		organisationID,
-	)
-
-	tenant, err := scanTenant(row)
+func (s *Server) TenantsGet(w http.ResponseWriter, r *http.Request, id string, params models.TenantsGetParams) {
+	if err := validateID(id, tenantResource); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	tenant, err := s.tenants.Get(r.Context(), organisationID, id)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.handleError(w, r, err, tenantResource)
 		return
 	}
 
-	s.logger.Debug("Tenant Retrieved", "tenant", tenant)
+	tenants := []models.Tenant{tenant}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(tenant)
-}
+	if err := s.expandTenants(r.Context(), organisationID, tenants, params.Expand); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
 
-func (s *Server) TenantsUpdate(w http.ResponseWriter, r *http.Request, id string) {
-	var payload UpdateTenant
-	err := json.NewDecoder(r.Body).Decode(&payload)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
-		return
-	}
-
-	setClause, values, paramCount := buildTenantUpdateSetClause(payload)
-
-	organisationID := r.Context().Value(types.OrgIDKey)
-	values = append(values, id, organisationID)
-
-	sql := fmt.Sprintf(`
-		UPDATE tenants 
-		%s
-		WHERE 
-			id = $%d
-			AND organisation_id = $%d
-		RETURNING 
-			id,
-			name,
-			email,
-			mobile,
-			phone,
-			paid_from,
-			paid_to,
-			rental_amount,
-			frequency,
-			original_start_date,
-			start_date,
-			end_date,
-			termination_date,
-			termination_reason,
-			vacate_date,
-			is_archived,
-			property_id,
-			created_at,
-			updated_at
-	`, setClause, paramCount+1, paramCount+2)
-
-	var updatedTenant Tenant
-
-	row := s.dbpool.QueryRow(
-		context.Background(),
-		sql,
-		values...,
-	)
-
-	updatedTenant, err = scanTenant(row)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(Error{
-			Code:    http.StatusInternalServerError,
-			Message: "Internal server error",
-		})
+	tenant = tenants[0]
+
+	fields := listParam(params.Fields)
+	v := tenantValidators(tenant).withFields(fields)
+
+	if v.notModified(w, params.IfNoneMatch, params.IfModifiedSince) {
 		return
 	}
 
-	s.logger.Debug("Tenant Updated", "tenant", updatedTenant)
+	s.log(r).Debug("Tenant Retrieved", "tenant", tenant)
+
+	body, err := sparseRecord(tenant, fields, "property")
 
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	v.set(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(updatedTenant)
+	json.NewEncoder(w).Encode(body)
 }
 
-func scanProperty(scanner interface {
-	Scan(dest ...interface{}) error
-}) (Property, error) {
-	var property Property
-
-	var managementGained pgtype.Date
-	var managementLost *pgtype.Date
-
-	err := scanner.Scan(
-		&property.Id,
-		&property.StreetNumber,
-		&property.StreetName,
-		&property.Suburb,
-		&property.State,
-		&property.Postcode,
-		&property.Country,
-		&property.LandlordId,
-		&property.ManagementFee,
-		&managementGained,
-		&managementLost,
-		&property.IsArchived,
-		&property.CreatedAt,
-		&property.UpdatedAt,
-	)
+func (s *Server) TenantsUpdate(w http.ResponseWriter, r *http.Request, id string, params models.TenantsUpdateParams) {
+	if err := validateID(id, tenantResource); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
 
+	var payload models.UpdateTenant
+	err := json.NewDecoder(r.Body).Decode(&payload)
 	if err != nil {
-		return property, err
+		writeError(w, invalidRequestError(err))
+		return
 	}
 
-	property.ManagementGained = openapi_types.Date{
-		Time: managementGained.Time,
-	}
+	versions, ok := ifMatch(params.IfMatch)
 
-	if managementLost != nil {
-		property.ManagementLost = &openapi_types.Date{
-			Time: managementLost.Time,
-		}
+	if !ok {
+		s.handleError(w, r, storage.ErrPreconditionFailed, tenantResource)
+		return
 	}
 
-	return property, nil
-}
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
 
-func scanTenant(scanner interface {
-	Scan(dest ...interface{}) error
-}) (Tenant, error) {
-	var tenant Tenant
-	var originalStartDate pgtype.Date
-	var startDate pgtype.Date
-	var endDate pgtype.Date
-	var terminationDate *pgtype.Date
-	var vacateDate *pgtype.Date
-
-	err := scanner.Scan(
-		&tenant.Id,
-		&tenant.Name,
-		&tenant.Email,
-		&tenant.Mobile,
-		&tenant.Phone,
-		&tenant.PaidFrom,
-		&tenant.PaidTo,
-		&tenant.RentalAmount,
-		&tenant.Frequency,
-		&originalStartDate,
-		&startDate,
-		&endDate,
-		&terminationDate,
-		&tenant.TerminationReason,
-		&vacateDate,
-		&tenant.IsArchived,
-		&tenant.PropertyId,
-		&tenant.CreatedAt,
-		&tenant.UpdatedAt,
-	)
-
-	tenant.OriginalStartDate.Time = originalStartDate.Time
-	tenant.StartDate.Time = startDate.Time
-	tenant.EndDate.Time = endDate.Time
-
-	if terminationDate != nil {
-		tenant.TerminationDate = &openapi_types.Date{Time: terminationDate.Time}
-	}
-
-	if vacateDate != nil {
-		tenant.VacateDate = &openapi_types.Date{Time: vacateDate.Time}
-	}
-
-	return tenant, err
-}
+	updatedTenant, err := s.tenants.Update(r.Context(), organisationID, id, payload, versions)
 
-func handleLandlordErrors(err error) Error {
-	if err == pgx.ErrNoRows {
-		return Error{Message: "No landlord found with the specified ID", Code: http.StatusNotFound}
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
 	}
 
-	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		if pgErr.Code == "22P02" {
-			return Error{Message: "Invalid Landlord ID format - must be a valid UUID", Code: http.StatusBadRequest}
-		}
-	}
+	s.log(r).Debug("Tenant Updated", "tenant", updatedTenant)
 
-	return Error{Message: "Internal server error", Code: http.StatusInternalServerError}
+	w.Header().Set("ETag", etag(updatedTenant.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updatedTenant)
 }
 
-func handlePaginationParams(params any) (int, int, int) {
+// handlePaginationParams reads the paging params shared by the list operations. It
+// returns the page to fetch and its number, which is 0 when paging by cursor.
+func handlePaginationParams(params any) (storage.Page, int, error) {
 	var pagePtr *int32
 	var limitPtr *int32
+	var after *string
+	var before *string
 
 	switch p := params.(type) {
-	case LandlordsListParams:
+	case models.LandlordsListParams:
 		pagePtr = p.Page
 		limitPtr = p.Limit
-	case TenantsListParams:
+		after = p.After
+		before = p.Before
+	case models.TenantsListParams:
 		pagePtr = p.Page
 		limitPtr = p.Limit
-	case PropertiesListParams:
+		after = p.After
+		before = p.Before
+	case models.PropertiesListParams:
 		pagePtr = p.Page
 		limitPtr = p.Limit
+		after = p.After
+		before = p.Before
+	case models.LandlordsListPropertiesParams:
+		pagePtr = p.Page
+		limitPtr = p.Limit
+		after = p.After
+		before = p.Before
+	case models.PropertiesListTenantsParams:
+		pagePtr = p.Page
+		limitPtr = p.Limit
+		after = p.After
+		before = p.Before
 	default:
 		// Optional: handle unexpected types
-		return 20, 1, 0 // default: page=1, limit=20
-	}
-
-	page := 1
-	if pagePtr != nil {
-		page = int(*pagePtr)
+		return storage.Page{Limit: 20}, 1, nil // default: page=1, limit=20
 	}
 
 	limit := 20
@@ -1400,303 +943,196 @@ func handlePaginationParams(params any) (int, int, int) {
 		limit = 100
 	}
 
-	offset := (page - 1) * limit
-	return limit, page, offset
-}
-
-// buildWhereClause constructs a SQL WHERE clause from a map of conditions
-// conditions is a map of column names to their search values.
-//
-// Returns the WHERE clause string, query parameters, and the next parameter number
-func buildWhereClause(conditions map[string]interface{}) (string, []interface{}, int) {
-	if len(conditions) == 0 {
-		return "", []interface{}{}, 1
+	if limit < 1 {
+		limit = 1
 	}
 
-	clauses := []string{}
-	params := []interface{}{}
-	paramCount := 1
-
-	for column, value := range conditions {
-		if value == nil {
-			continue
+	if after != nil || before != nil {
+		if after != nil && before != nil {
+			return storage.Page{}, 0, newError(http.StatusBadRequest, models.InvalidRequest, "Only one of after or before can be given")
 		}
 
-		switch column {
-		case "name", "full_address":
-			if v, ok := value.(*string); ok && v != nil {
-				clauses = append(clauses, fmt.Sprintf("%s ILIKE $%d", column, paramCount))
-				params = append(params, "%"+*v+"%")
-				paramCount++
-			}
-		case "archived_only":
-			if v, ok := value.(*bool); ok && v != nil && *v {
-				clauses = append(clauses, "is_archived is not null")
-			} else {
-				clauses = append(clauses, "is_archived is null")
-			}
-		default:
-			clauses = append(clauses, fmt.Sprintf("%s = $%d", column, paramCount))
-			params = append(params, value)
-			paramCount++
+		if pagePtr != nil {
+			return storage.Page{}, 0, newError(http.StatusBadRequest, models.InvalidRequest, "page can't be combined with after or before")
 		}
+
+		return storage.Page{Limit: limit, After: after, Before: before}, 0, nil
 	}
 
-	if len(clauses) == 0 {
-		return "", []interface{}{}, 1
+	page := 1
+	if pagePtr != nil {
+		page = int(*pagePtr)
 	}
 
-	return "WHERE " + strings.Join(clauses, "\nAND "), params, paramCount
+	offset := (page - 1) * limit
+	return storage.Page{Limit: limit, Offset: offset}, page, nil
 }
 
-func buildLandlordUpdateSetClause(payload UpdateLandlord) (string, []interface{}, int) {
-	fields := []string{}
-	values := []interface{}{}
-	paramCount := 0
-
-	if payload.Name != nil && *payload.Name != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("name = $%d", paramCount))
-		values = append(values, *payload.Name)
-	}
+// canonicalID returns a path ID validated by validateID in the lowercase form
+// Postgres prints UUIDs in, so it can be compared as text in a storage.Set
+func canonicalID(id string) string {
+	return uuid.MustParse(id).String()
+}
 
-	if payload.Email != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("email = $%d", paramCount))
-		values = append(values, *payload.Email)
+// listParam returns the values of an optional list param, such as sort or fields
+func listParam(values *[]string) []string {
+	if values == nil {
+		return nil
 	}
 
-	if payload.Mobile != nil && *payload.Mobile != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("mobile = $%d", paramCount))
-		values = append(values, *payload.Mobile)
-	}
+	return *values
+}
 
-	if payload.Phone != nil && *payload.Phone != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("phone = $%d", paramCount))
-		values = append(values, *payload.Phone)
+// queryFields returns the fields to select for a sparse fieldset, adding the foreign
+// keys of the records it's expanded with
+func queryFields(fields []string, foreignKeys ...string) []string {
+	if len(fields) == 0 {
+		return nil
 	}
 
-	if payload.AddressLine1 != nil && *payload.AddressLine1 != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("address_line_1 = $%d", paramCount))
-		values = append(values, *payload.AddressLine1)
-	}
+	return slices.Concat(fields, foreignKeys)
+}
 
-	if payload.AddressLine2 != nil && *payload.AddressLine2 != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("address_line_2 = $%d", paramCount))
-		values = append(values, *payload.AddressLine2)
+// sparseList limits the items in a list response to the fields of a sparse fieldset,
+// plus id and any records added by expand. The response is returned unchanged when no
+// fields are given.
+func sparseList(resp any, fields []string, expanded ...string) (any, error) {
+	if len(fields) == 0 {
+		return resp, nil
 	}
 
-	if payload.Suburb != nil && *payload.Suburb != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("suburb = $%d", paramCount))
-		values = append(values, *payload.Suburb)
-	}
+	b, err := json.Marshal(resp)
 
-	if payload.Postcode != nil && *payload.Postcode != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("postcode = $%d", paramCount))
-		values = append(values, *payload.Postcode)
+	if err != nil {
+		return nil, err
 	}
 
-	if payload.State != nil && *payload.State != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("state = $%d", paramCount))
-		values = append(values, *payload.State)
-	}
+	var body map[string]json.RawMessage
 
-	if payload.Country != nil && *payload.Country != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("country = $%d", paramCount))
-		values = append(values, *payload.Country)
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
 	}
 
-	if payload.IsArchived == nil {
-		fields = append(fields, "is_archived = null")
-	}
+	var items []map[string]json.RawMessage
 
-	if len(fields) > 0 {
-		fields = append(fields, "updated_at = NOW()")
+	if err := json.Unmarshal(body["items"], &items); err != nil {
+		return nil, err
 	}
 
-	setClause := "SET\n" + strings.Join(fields, ",\n")
-
-	return setClause, values, paramCount
-}
-
-func buildPropertyUpdateSetClause(payload UpdateProperty) (string, []interface{}, int) {
-	fields := []string{}
-	values := []interface{}{}
-	paramCount := 0
-
-	if payload.StreetNumber != nil && *payload.StreetNumber != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("street_number = $%d", paramCount))
-		values = append(values, *payload.StreetNumber)
+	for _, item := range items {
+		keepFields(item, fields, expanded)
 	}
 
-	if payload.StreetName != nil && *payload.StreetName != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("street_name = $%d", paramCount))
-		values = append(values, *payload.StreetName)
+	if body["items"], err = json.Marshal(items); err != nil {
+		return nil, err
 	}
 
-	if payload.Suburb != nil && *payload.Suburb != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("suburb = $%d", paramCount))
-		values = append(values, *payload.Suburb)
-	}
+	return body, nil
+}
 
-	if payload.Postcode != nil && *payload.Postcode != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("postcode = $%d", paramCount))
-		values = append(values, *payload.Postcode)
+// sparseRecord limits a record to the fields of a sparse fieldset, plus id and any
+// records added by expand. The record is returned unchanged when no fields are given.
+func sparseRecord(record any, fields []string, expanded ...string) (any, error) {
+	if len(fields) == 0 {
+		return record, nil
 	}
 
-	if payload.State != nil && *payload.State != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("state = $%d", paramCount))
-		values = append(values, *payload.State)
-	}
+	b, err := json.Marshal(record)
 
-	if payload.Country != nil && *payload.Country != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("country = $%d", paramCount))
-		values = append(values, *payload.Country)
+	if err != nil {
+		return nil, err
 	}
 
-	if payload.ManagementFee != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("management_fee = $%d", paramCount))
-		values = append(values, *payload.ManagementFee)
-	}
+	var body map[string]json.RawMessage
 
-	if payload.ManagementGained != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("management_gained = $%d", paramCount))
-		values = append(values, *payload.ManagementGained)
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
 	}
 
-	if payload.ManagementLost != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("management_lost = $%d", paramCount))
-		values = append(values, *payload.ManagementLost)
-	}
+	keepFields(body, fields, expanded)
 
-	if payload.IsArchived == nil {
-		fields = append(fields, "is_archived = null")
-	}
-
-	if len(fields) > 0 {
-		fields = append(fields, "updated_at = NOW()")
-	}
+	return body, nil
+}
 
-	setClause := "SET\n" + strings.Join(fields, ",\n")
+// keepFields removes the fields of an encoded record that aren't in a sparse
+// fieldset, id or expanded
+func keepFields(record map[string]json.RawMessage, fields []string, expanded []string) {
+	keep := slices.Concat(fields, []string{"id"}, expanded)
 
-	return setClause, values, paramCount
+	maps.DeleteFunc(record, func(field string, _ json.RawMessage) bool {
+		return !slices.Contains(keep, field)
+	})
 }
 
-func buildTenantUpdateSetClause(payload UpdateTenant) (string, []interface{}, int) {
-	fields := []string{}
-	values := []interface{}{}
-	paramCount := 0
-
-	if payload.Name != nil && *payload.Name != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("name = $%d", paramCount))
-		values = append(values, *payload.Name)
+// stringSet returns the values of a list filter, which is optional
+func stringSet[T ~string](values *[]T) storage.Set {
+	if values == nil {
+		return nil
 	}
 
-	if payload.Email != nil && *payload.Email != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("email = $%d", paramCount))
-		values = append(values, *payload.Email)
-	}
+	set := make(storage.Set, len(*values))
 
-	if payload.Mobile != nil && *payload.Mobile != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("mobile = $%d", paramCount))
-		values = append(values, *payload.Mobile)
+	for i, v := range *values {
+		set[i] = string(v)
 	}
 
-	if payload.Phone != nil && *payload.Phone != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("phone = $%d", paramCount))
-		values = append(values, *payload.Phone)
-	}
+	return set
+}
 
-	if payload.PaidFrom != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("paid_from = $%d", paramCount))
-		values = append(values, *payload.PaidFrom)
+// idSet returns the IDs in a list filter, which is optional
+func idSet(ids *[]openapi_types.UUID) storage.Set {
+	if ids == nil {
+		return nil
 	}
 
-	if payload.PaidTo != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("paid_to = $%d", paramCount))
-		values = append(values, *payload.PaidTo)
-	}
+	set := make(storage.Set, len(*ids))
 
-	if payload.RentalAmount != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("rental_amount = $%d", paramCount))
-		values = append(values, *payload.RentalAmount)
+	for i, id := range *ids {
+		set[i] = id.String()
 	}
 
-	if payload.Frequency != nil && *payload.Frequency != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("frequency = $%d", paramCount))
-		values = append(values, *payload.Frequency)
-	}
+	return set
+}
 
-	if payload.OriginalStartDate != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("original_start_date = $%d", paramCount))
-		values = append(values, *payload.OriginalStartDate)
-	}
+// dateRange returns the range between two optional dates
+func dateRange(from *openapi_types.Date, to *openapi_types.Date) storage.Range[time.Time] {
+	var dates storage.Range[time.Time]
 
-	if payload.StartDate != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("start_date = $%d", paramCount))
-		values = append(values, *payload.StartDate)
+	if from != nil {
+		dates.From = &from.Time
 	}
 
-	if payload.EndDate != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("end_date = $%d", paramCount))
-		values = append(values, *payload.EndDate)
+	if to != nil {
+		dates.To = &to.Time
 	}
 
-	if payload.TerminationDate != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("termination_date = $%d", paramCount))
-		values = append(values, *payload.TerminationDate)
-	}
+	return dates
+}
 
-	if payload.TerminationReason != nil && *payload.TerminationReason != "" {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("termination_reason = $%d", paramCount))
-		values = append(values, *payload.TerminationReason)
-	}
+// paginationMetadata describes a page returned by a list operation. Page numbers and
+// totals are only known when paging by offset.
+func paginationMetadata(page storage.Page, pageNumber int, count int, info storage.PageInfo) (*models.PaginatedMetadata, models.CursorMetadata) {
+	var cursors models.CursorMetadata
 
-	if payload.VacateDate != nil {
-		paramCount++
-		fields = append(fields, fmt.Sprintf("vacate_date = $%d", paramCount))
-		values = append(values, *payload.VacateDate)
+	if info.Next != "" {
+		cursors.Next = &info.Next
 	}
 
-	if payload.IsArchived == nil {
-		fields = append(fields, "is_archived = null")
+	if info.Previous != "" {
+		cursors.Previous = &info.Previous
 	}
 
-	if len(fields) > 0 {
-		fields = append(fields, "updated_at = NOW()")
+	if info.Total == nil {
+		return nil, cursors
 	}
 
-	setClause := "SET\n" + strings.Join(fields, ",\n")
+	total := *info.Total
 
-	return setClause, values, paramCount
+	return &models.PaginatedMetadata{
+		Total:       int32(total),
+		Count:       int32(count),
+		PerPage:     int32(page.Limit),
+		CurrentPage: int32(pageNumber),
+		TotalPages:  int32(math.Ceil(float64(total) / float64(page.Limit))),
+	}, cursors
 }
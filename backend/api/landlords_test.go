@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/storage/storagetest"
+	"github.com/davidtaing/property-management/internal/types"
+	"github.com/google/uuid"
+)
+
+// newTestRequest returns a request made in the organisation, as the auth middleware
+// would pass it on
+func newTestRequest(t *testing.T, organisationID string, method string, body any) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encoding request: %v", err)
+		}
+	}
+
+	r := httptest.NewRequest(method, "/", &buf)
+
+	return r.WithContext(context.WithValue(r.Context(), types.OrgIDKey, organisationID))
+}
+
+func decodeResponse[T any](t *testing.T, rec *httptest.ResponseRecorder, status int) T {
+	t.Helper()
+
+	var body T
+
+	if rec.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	return body
+}
+
+func TestLandlordHandlers(t *testing.T) {
+	s := NewServer(storage.Repositories{Landlords: storagetest.NewLandlordRepository()}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	rec := httptest.NewRecorder()
+	s.LandlordsCreate(rec, newTestRequest(t, "org_1", http.MethodPost, models.CreateLandlord{
+		Name:         "Jane Citizen",
+		Email:        "landlord@example.com",
+		Mobile:       "0400000000",
+		AddressLine1: "1 George Street",
+		Suburb:       "Sydney",
+		State:        "NSW",
+		Postcode:     "2000",
+		Country:      "Australia",
+	}), models.LandlordsCreateParams{})
+
+	created := decodeResponse[models.Landlord](t, rec, http.StatusCreated)
+	tag := rec.Header().Get("ETag")
+	id := created.Id.String()
+
+	if tag != etag(created.UpdatedAt) {
+		t.Errorf("expected ETag %s, got %s", etag(created.UpdatedAt), tag)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_1", http.MethodGet, nil), id, models.LandlordsGetParams{})
+
+		got := decodeResponse[models.Landlord](t, rec, http.StatusOK)
+
+		if got.Name != created.Name {
+			t.Errorf("expected landlord %q, got %q", created.Name, got.Name)
+		}
+
+		rec = httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_1", http.MethodGet, nil), id, models.LandlordsGetParams{IfNoneMatch: &tag})
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected status %d, got %d", http.StatusNotModified, rec.Code)
+		}
+	})
+
+	t.Run("get fields", func(t *testing.T) {
+		fields := []models.LandlordField{"name"}
+
+		rec := httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_1", http.MethodGet, nil), id, models.LandlordsGetParams{Fields: &fields})
+
+		got := decodeResponse[map[string]any](t, rec, http.StatusOK)
+
+		if len(got) != 2 || got["id"] != id || got["name"] != created.Name {
+			t.Errorf("expected only the id and name, got %v", got)
+		}
+
+		sparseTag := rec.Header().Get("ETag")
+
+		if _, ok := parseETag(sparseTag); ok || sparseTag == tag {
+			t.Errorf("expected a weak ETag for the fields, got %s", sparseTag)
+		}
+
+		// the full landlord's tag doesn't match the sparse one
+		rec = httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_1", http.MethodGet, nil), id, models.LandlordsGetParams{Fields: &fields, IfNoneMatch: &tag})
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_1", http.MethodGet, nil), id, models.LandlordsGetParams{Fields: &fields, IfNoneMatch: &sparseTag})
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected status %d, got %d", http.StatusNotModified, rec.Code)
+		}
+	})
+
+	t.Run("other organisation", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.LandlordsGet(rec, newTestRequest(t, "org_2", http.MethodGet, nil), id, models.LandlordsGetParams{})
+
+		got := decodeResponse[models.Error](t, rec, http.StatusNotFound)
+
+		if got.ErrorCode != models.NotFound {
+			t.Errorf("expected error %s, got %s", models.NotFound, got.ErrorCode)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		name := "John Smith"
+		payload := models.UpdateLandlord{Name: &name}
+
+		rec := httptest.NewRecorder()
+		s.LandlordsUpdate(rec, newTestRequest(t, "org_1", http.MethodPatch, payload), id, models.LandlordsUpdateParams{IfMatch: &tag})
+
+		updated := decodeResponse[models.Landlord](t, rec, http.StatusOK)
+
+		if updated.Name != name || updated.Email != created.Email {
+			t.Errorf("expected only the name to change, got %+v", updated)
+		}
+
+		// the landlord has changed since tag
+		rec = httptest.NewRecorder()
+		s.LandlordsUpdate(rec, newTestRequest(t, "org_1", http.MethodPatch, payload), id, models.LandlordsUpdateParams{IfMatch: &tag})
+
+		got := decodeResponse[models.Error](t, rec, http.StatusPreconditionFailed)
+
+		if got.ErrorCode != models.PreconditionFailed {
+			t.Errorf("expected error %s, got %s", models.PreconditionFailed, got.ErrorCode)
+		}
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.LandlordsUpdate(rec, newTestRequest(t, "org_1", http.MethodPatch, map[string]any{"phone": "0299999999"}), id, models.LandlordsUpdateParams{})
+
+		if updated := decodeResponse[models.Landlord](t, rec, http.StatusOK); updated.Phone == nil {
+			t.Fatal("expected the phone to be set")
+		}
+
+		rec = httptest.NewRecorder()
+		s.LandlordsUpdate(rec, newTestRequest(t, "org_1", http.MethodPatch, map[string]any{"phone": nil}), id, models.LandlordsUpdateParams{})
+
+		if updated := decodeResponse[models.Landlord](t, rec, http.StatusOK); updated.Phone != nil {
+			t.Errorf("expected the phone to be cleared, got %s", *updated.Phone)
+		}
+	})
+}
+
+func TestLandlordsBatch(t *testing.T) {
+	s := NewServer(storage.Repositories{Landlords: storagetest.NewLandlordRepository()}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	atomic := false
+	missing := uuid.New()
+	create := []models.CreateLandlord{{
+		Name:         "Jane Citizen",
+		Email:        "landlord@example.com",
+		Mobile:       "0400000000",
+		AddressLine1: "1 George Street",
+		Suburb:       "Sydney",
+		State:        "NSW",
+		Postcode:     "2000",
+		Country:      "Australia",
+	}}
+	update := []models.LandlordBatchUpdate{{Id: missing, Changes: models.UpdateLandlord{}}}
+
+	rec := httptest.NewRecorder()
+	s.LandlordsBatch(rec, newTestRequest(t, "org_1", http.MethodPost, models.LandlordBatch{Atomic: &atomic, Create: &create, Update: &update}))
+
+	results := decodeResponse[models.LandlordBatchResults](t, rec, http.StatusOK)
+
+	if results.Succeeded != 1 || results.Failed != 1 {
+		t.Errorf("expected 1 success and 1 failure, got %d and %d", results.Succeeded, results.Failed)
+	}
+
+	if len(results.Create) != 1 || results.Create[0].Landlord == nil || results.Create[0].Etag == nil || results.Create[0].Status != http.StatusCreated {
+		t.Errorf("expected the created landlord and its ETag, got %+v", results.Create)
+	}
+
+	if len(results.Update) != 1 || results.Update[0].Error == nil || results.Update[0].Status != http.StatusNotFound {
+		t.Errorf("expected the update of a missing landlord to fail, got %+v", results.Update)
+	}
+
+	if results.Archive == nil {
+		t.Error("expected an empty list of archives, got null")
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+func (s *Server) MaintenanceRequestsList(w http.ResponseWriter, r *http.Request, params models.MaintenanceRequestsListParams) {
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	maintenanceRequests, err := s.maintenanceRequests.List(r.Context(), organisationID, params.Status, listParam(params.Sort))
+
+	if err != nil {
+		s.handleError(w, r, err, maintenanceRequestResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.MaintenanceRequestList{Items: maintenanceRequests})
+}
+
+func (s *Server) MaintenanceRequestsUpdate(w http.ResponseWriter, r *http.Request, id string) {
+	if err := validateID(id, maintenanceRequestResource); err != nil {
+		s.handleError(w, r, err, maintenanceRequestResource)
+		return
+	}
+
+	var payload models.UpdateMaintenanceRequest
+	err := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	updatedMaintenanceRequest, err := s.maintenanceRequests.Update(r.Context(), organisationID, id, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, maintenanceRequestResource)
+		return
+	}
+
+	s.log(r).Debug("Maintenance Request Updated", "maintenance_request", updatedMaintenanceRequest)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updatedMaintenanceRequest)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// orgAdminRole is the Clerk organisation role allowed to change organisation settings
+// and grant portal access
+const orgAdminRole = "org:admin"
+
+var (
+	abnPattern      = regexp.MustCompile(`^\d{11}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	colourPattern   = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+func (s *Server) OrganisationSettingsGet(w http.ResponseWriter, r *http.Request) {
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	organisation, err := s.organisations.Get(r.Context(), organisationID)
+
+	if err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	s.log(r).Debug("Organisation Retrieved", "organisation", organisation)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(organisation)
+}
+
+func (s *Server) OrganisationSettingsUpdate(w http.ResponseWriter, r *http.Request) {
+	if !isOrgAdmin(r) {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only organisation admins can update organisation settings"))
+		return
+	}
+
+	var payload models.UpdateOrganisation
+	err := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	if err := validateOrganisationUpdate(payload); err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	updatedOrganisation, err := s.organisations.Update(r.Context(), organisationID, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	s.log(r).Debug("Organisation Updated", "organisation", updatedOrganisation)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updatedOrganisation)
+}
+
+// isOrgAdmin reports whether the current user is an admin of their organisation
+func isOrgAdmin(r *http.Request) bool {
+	role, _ := r.Context().Value(types.OrgRoleKey).(string)
+
+	return role == orgAdminRole
+}
+
+// agency returns the organisation's details shown to portal users
+func agency(organisation models.Organisation) models.Agency {
+	return models.Agency{
+		TradingName:   organisation.TradingName,
+		LicenceNumber: organisation.LicenceNumber,
+		Abn:           organisation.Abn,
+		Branding:      organisation.Branding,
+	}
+}
+
+// validateOrganisationUpdate returns a validation error naming the first invalid field, or nil
+func validateOrganisationUpdate(payload models.UpdateOrganisation) error {
+	if abn, err := payload.Abn.Get(); err == nil && !abnPattern.MatchString(strings.ReplaceAll(abn, " ", "")) {
+		return validationError("abn", "ABN must contain 11 digits")
+	}
+
+	if payload.Timezone != nil {
+		if _, err := time.LoadLocation(*payload.Timezone); err != nil || *payload.Timezone == "" {
+			return validationError("timezone", "Timezone must be an IANA time zone name, e.g. Australia/Sydney")
+		}
+	}
+
+	if payload.Currency != nil && !currencyPattern.MatchString(*payload.Currency) {
+		return validationError("currency", "Currency must be an ISO 4217 currency code, e.g. AUD")
+	}
+
+	if fee, err := payload.DefaultManagementFee.Get(); err == nil && fee < 0 {
+		return validationError("default_management_fee", "Default management fee cannot be negative")
+	}
+
+	if interval, err := payload.DefaultInspectionInterval.Get(); err == nil && interval < 1 {
+		return validationError("default_inspection_interval", "Default inspection interval must be at least 1 month")
+	}
+
+	if payload.Branding != nil {
+		if logoURL, err := payload.Branding.LogoUrl.Get(); err == nil {
+			u, err := url.ParseRequestURI(logoURL)
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+				return validationError("branding.logo_url", "Logo URL must be an absolute http(s) URL")
+			}
+		}
+
+		if colour, err := payload.Branding.PrimaryColour.Get(); err == nil && !colourPattern.MatchString(colour) {
+			return validationError("branding.primary_colour", "Primary colour must be a hex colour, e.g. #1A2B3C")
+		}
+	}
+
+	return nil
+}
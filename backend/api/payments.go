@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+func (s *Server) TenantsListPayments(w http.ResponseWriter, r *http.Request, id string) {
+	if err := validateID(id, tenantResource); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	// a tenant that doesn't exist is a 404 rather than an empty list
+	if _, err := s.tenants.Get(r.Context(), organisationID, id); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	payments, err := s.payments.List(r.Context(), organisationID, id)
+
+	if err != nil {
+		s.handleError(w, r, err, paymentResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.PaymentList{Items: payments})
+}
+
+func (s *Server) TenantsCreatePayment(w http.ResponseWriter, r *http.Request, id string) {
+	if err := validateID(id, tenantResource); err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	var payload models.CreatePayment
+	err := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	createdPayment, err := s.payments.Create(r.Context(), organisationID, id, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	s.log(r).Debug("Payment Created", "payment", createdPayment)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdPayment)
+}
@@ -0,0 +1,344 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// Portal users only ever see records linked to their own landlord or tenant record.
+// Landlords get statements of the rent received on their properties, and tenants a
+// ledger of their own rent, both from the payments staff record.
+
+func (s *Server) PortalUsersList(w http.ResponseWriter, r *http.Request, params models.PortalUsersListParams) {
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	portalUsers, err := s.portalUsers.List(r.Context(), organisationID, listParam(params.Sort))
+
+	if err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.PortalUserList{Items: portalUsers})
+}
+
+func (s *Server) PortalUsersCreate(w http.ResponseWriter, r *http.Request) {
+	if !isOrgAdmin(r) {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only organisation admins can grant portal access"))
+		return
+	}
+
+	var payload models.CreatePortalUser
+	err := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	if (payload.LandlordId == nil) == (payload.TenantId == nil) {
+		writeError(w, validationError("landlord_id", "Exactly one of landlord_id or tenant_id must be given"))
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	createdPortalUser, err := s.portalUsers.Create(r.Context(), organisationID, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return
+	}
+
+	s.log(r).Debug("Portal User Created", "portal_user", createdPortalUser)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdPortalUser)
+}
+
+func (s *Server) PortalUsersRevoke(w http.ResponseWriter, r *http.Request, id string) {
+	if !isOrgAdmin(r) {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only organisation admins can revoke portal access"))
+		return
+	}
+
+	if err := validateID(id, portalUserResource); err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	revokedPortalUser, err := s.portalUsers.Revoke(r.Context(), organisationID, id)
+
+	if err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return
+	}
+
+	s.log(r).Debug("Portal User Revoked", "portal_user", revokedPortalUser)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(revokedPortalUser)
+}
+
+func (s *Server) PortalMe(w http.ResponseWriter, r *http.Request, params models.PortalMeParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	organisation, err := s.organisations.Get(r.Context(), organisationID)
+
+	if err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	identity := models.PortalIdentity{
+		UserId:         portalUser.UserId,
+		OrganisationId: organisationID,
+		Agency:         agency(organisation),
+	}
+
+	if portalUser.LandlordId != nil {
+		identity.Role = models.PortalRoleLandlord
+
+		landlord, err := s.landlords.Get(r.Context(), organisationID, portalUser.LandlordId.String())
+
+		if err != nil {
+			s.handleError(w, r, err, portalUserResource)
+			return
+		}
+
+		identity.Landlord = &landlord
+	} else {
+		identity.Role = models.PortalRoleTenant
+
+		tenant, err := s.tenants.Get(r.Context(), organisationID, portalUser.TenantId.String())
+
+		if err != nil {
+			s.handleError(w, r, err, portalUserResource)
+			return
+		}
+
+		identity.Tenant = &tenant
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(identity)
+}
+
+func (s *Server) PortalOrganisations(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(types.UserIDKey).(string)
+
+	organisations, err := s.portalUsers.ListOrganisations(r.Context(), userID)
+
+	if err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.PortalOrganisationList{Items: organisations})
+}
+
+func (s *Server) PortalProperties(w http.ResponseWriter, r *http.Request, params models.PortalPropertiesParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	properties, err := s.properties.ListForPortalUser(r.Context(), organisationID, portalUser)
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.PortalPropertyList{Items: properties})
+}
+
+func (s *Server) PortalTenancies(w http.ResponseWriter, r *http.Request, params models.PortalTenanciesParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	tenancies, err := s.tenants.ListTenanciesForPortalUser(r.Context(), organisationID, portalUser)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.PortalTenancyList{Items: tenancies})
+}
+
+func (s *Server) PortalListMaintenanceRequests(w http.ResponseWriter, r *http.Request, params models.PortalListMaintenanceRequestsParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	maintenanceRequests, err := s.maintenanceRequests.ListForPortalUser(r.Context(), organisationID, portalUser)
+
+	if err != nil {
+		s.handleError(w, r, err, maintenanceRequestResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.MaintenanceRequestList{Items: maintenanceRequests})
+}
+
+func (s *Server) PortalCreateMaintenanceRequest(w http.ResponseWriter, r *http.Request, params models.PortalCreateMaintenanceRequestParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	if portalUser.TenantId == nil {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only tenants can lodge maintenance requests"))
+		return
+	}
+
+	var payload models.CreateMaintenanceRequest
+	err := json.NewDecoder(r.Body).Decode(&payload)
+
+	if err != nil {
+		writeError(w, invalidRequestError(err))
+		return
+	}
+
+	createdMaintenanceRequest, err := s.maintenanceRequests.CreateForTenant(r.Context(), organisationID, *portalUser.TenantId, payload)
+
+	if err != nil {
+		s.handleError(w, r, err, maintenanceRequestResource)
+		return
+	}
+
+	s.log(r).Debug("Maintenance Request Created", "maintenance_request", createdMaintenanceRequest)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdMaintenanceRequest)
+}
+
+func (s *Server) PortalLedger(w http.ResponseWriter, r *http.Request, params models.PortalLedgerParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	if portalUser.TenantId == nil {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only tenants have a rent ledger"))
+		return
+	}
+
+	organisation, err := s.organisations.Get(r.Context(), organisationID)
+
+	if err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	ledger, err := s.payments.Ledger(r.Context(), organisationID, *portalUser.TenantId)
+
+	if err != nil {
+		s.handleError(w, r, err, tenantResource)
+		return
+	}
+
+	ledger.Agency = agency(organisation)
+	ledger.Currency = organisation.Currency
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ledger)
+}
+
+func (s *Server) PortalStatement(w http.ResponseWriter, r *http.Request, params models.PortalStatementParams) {
+	portalUser, organisationID, ok := s.resolvePortalUser(w, r, params.PortalOrganisation)
+	if !ok {
+		return
+	}
+
+	if portalUser.LandlordId == nil {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "Only landlords have statements"))
+		return
+	}
+
+	if params.To.Before(params.From.Time) {
+		writeError(w, newFieldError(http.StatusBadRequest, models.InvalidRequest, "to", "to can't be before from"))
+		return
+	}
+
+	if params.To.After(params.From.AddDate(1, 0, 0)) {
+		writeError(w, newFieldError(http.StatusBadRequest, models.InvalidRequest, "to", "A statement can cover at most a year"))
+		return
+	}
+
+	organisation, err := s.organisations.Get(r.Context(), organisationID)
+
+	if err != nil {
+		s.handleError(w, r, err, organisationResource)
+		return
+	}
+
+	statement, err := s.payments.Statement(r.Context(), organisationID, *portalUser.LandlordId, params.From, params.To)
+
+	if err != nil {
+		s.handleError(w, r, err, paymentResource)
+		return
+	}
+
+	statement.Agency = agency(organisation)
+	statement.Currency = organisation.Currency
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(statement)
+}
+
+// resolvePortalUser looks up the portal identity of the current user in the
+// organisation from the Portal-Organisation header, which can be left out when the
+// user only has access in one. When the user has no portal identity there a 403 is
+// written and ok is false.
+func (s *Server) resolvePortalUser(w http.ResponseWriter, r *http.Request, portalOrganisation *string) (portalUser models.PortalUser, organisationID string, ok bool) {
+	userID, _ := r.Context().Value(types.UserIDKey).(string)
+
+	if portalOrganisation != nil {
+		organisationID = *portalOrganisation
+	}
+
+	portalUser, organisationID, err := s.portalUsers.GetByUserID(r.Context(), userID, organisationID)
+
+	if errors.Is(err, storage.ErrNotFound) {
+		writeError(w, newError(http.StatusForbidden, models.Forbidden, "No portal access has been granted to this user"))
+		return portalUser, organisationID, false
+	}
+
+	if err != nil {
+		s.handleError(w, r, err, portalUserResource)
+		return portalUser, organisationID, false
+	}
+
+	return portalUser, organisationID, true
+}
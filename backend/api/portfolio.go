@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// assigneeMe can be used in place of a user ID to refer to the current user
+const assigneeMe = "me"
+
+func (s *Server) PortfoliosGet(w http.ResponseWriter, r *http.Request, userId string) {
+	userId = *resolveAssignee(r, &userId)
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	summary, err := s.properties.PortfolioSummary(r.Context(), organisationID, userId)
+
+	if err != nil {
+		s.handleError(w, r, err, propertyResource)
+		return
+	}
+
+	s.log(r).Debug("Portfolio Summary Retrieved", "portfolio", summary)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(summary)
+}
+
+// resolveAssignee replaces `me` with the current user's ID
+func resolveAssignee(r *http.Request, assignee *string) *string {
+	if assignee == nil || *assignee != assigneeMe {
+		return assignee
+	}
+
+	userID, _ := r.Context().Value(types.UserIDKey).(string)
+
+	return &userID
+}
+
+// assigneeCheck returns a check that an assignee is a member of the request's
+// organisation. An empty assignee unassigns, and the current user is a member of the
+// organisation they're signed in to, so neither is looked up. It remembers the users
+// it's looked up, for batches that assign many properties to the same few.
+func (s *Server) assigneeCheck(r *http.Request) func(ctx context.Context, assignee *string) error {
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+	userID, _ := r.Context().Value(types.UserIDKey).(string)
+
+	checked := map[string]bool{"": true, userID: true}
+
+	return func(ctx context.Context, assignee *string) error {
+		if assignee == nil {
+			return nil
+		}
+
+		member, ok := checked[*assignee]
+
+		if !ok {
+			var err error
+			member, err = s.members.IsMember(ctx, organisationID, *assignee)
+
+			if err != nil {
+				return err
+			}
+
+			checked[*assignee] = member
+		}
+
+		if !member {
+			return validationError("assigned_to", "No member of the organisation found with the specified assigned_to")
+		}
+
+		return nil
+	}
+}
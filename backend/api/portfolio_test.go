@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// fakeDirectory has a single member, and counts its lookups
+type fakeDirectory struct {
+	member  string
+	lookups int
+}
+
+func (d *fakeDirectory) IsMember(ctx context.Context, organisationID string, userID string) (bool, error) {
+	d.lookups++
+
+	return userID == d.member, nil
+}
+
+func TestAssigneeCheck(t *testing.T) {
+	directory := &fakeDirectory{member: "user_2"}
+	s := NewServer(storage.Repositories{}, directory, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	r := httptest.NewRequest(http.MethodPost, "/properties", nil)
+	r = r.WithContext(context.WithValue(r.Context(), types.OrgIDKey, "org_1"))
+	r = r.WithContext(context.WithValue(r.Context(), types.UserIDKey, "user_1"))
+
+	check := s.assigneeCheck(r)
+
+	assignee := func(userID string) *string {
+		return &userID
+	}
+
+	tests := []struct {
+		name     string
+		assignee *string
+		valid    bool
+	}{
+		{"unchanged", nil, true},
+		{"unassigned", assignee(""), true},
+		{"current user", assignee("user_1"), true},
+		{"member", assignee("user_2"), true},
+		{"member again", assignee("user_2"), true},
+		{"not a member", assignee("user_3"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check(r.Context(), tt.assignee)
+
+			var apiError models.Error
+
+			if tt.valid && err != nil {
+				t.Fatalf("expected the assignee to be allowed, got %v", err)
+			}
+
+			if !tt.valid && (!errors.As(err, &apiError) || apiError.Code != http.StatusUnprocessableEntity) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+		})
+	}
+
+	// the current user isn't looked up, and members are only looked up once
+	if directory.lookups != 2 {
+		t.Errorf("expected 2 lookups, got %d", directory.lookups)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/types"
+)
+
+// minSearchLength is the shortest query that's searched for, after trimming spaces
+const minSearchLength = 2
+
+func (s *Server) SearchQuery(w http.ResponseWriter, r *http.Request, params models.SearchQueryParams) {
+	query := strings.TrimSpace(params.Q)
+
+	if utf8.RuneCountInString(query) < minSearchLength {
+		writeError(w, newFieldError(http.StatusBadRequest, models.InvalidRequest, "q", "q must be at least 2 characters, not counting spaces"))
+		return
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	filter := storage.SearchFilter{
+		Query: query,
+		Limit: limit,
+	}
+
+	if params.Type != nil {
+		filter.Types = *params.Type
+	}
+
+	organisationID, _ := r.Context().Value(types.OrgIDKey).(string)
+
+	hits, err := s.search.Search(r.Context(), organisationID, filter)
+
+	if err != nil {
+		s.handleError(w, r, err, searchResource)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.SearchResults{Items: hits})
+}
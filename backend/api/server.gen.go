@@ -13,242 +13,13 @@ import (
 	"net/url"
 	"path"
 	"strings"
-	"time"
 
+	. "github.com/davidtaing/property-management/internal/models"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gorilla/mux"
 	"github.com/oapi-codegen/runtime"
-	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-const (
-	BearerAuthScopes = "BearerAuth.Scopes"
-)
-
-// CreateLandlord defines model for CreateLandlord.
-type CreateLandlord struct {
-	AddressLine1 string              `json:"address_line_1"`
-	AddressLine2 *string             `json:"address_line_2,omitempty"`
-	Country      string              `json:"country"`
-	Email        openapi_types.Email `json:"email"`
-	Mobile       string              `json:"mobile"`
-	Name         string              `json:"name"`
-	Phone        *string             `json:"phone,omitempty"`
-	Postcode     string              `json:"postcode"`
-	State        string              `json:"state"`
-	Suburb       string              `json:"suburb"`
-}
-
-// CreateProperty defines model for CreateProperty.
-type CreateProperty struct {
-	Country          string             `json:"country"`
-	LandlordId       openapi_types.UUID `json:"landlord_id"`
-	ManagementFee    float64            `json:"management_fee"`
-	ManagementGained openapi_types.Date `json:"management_gained"`
-	Postcode         string             `json:"postcode"`
-	State            string             `json:"state"`
-	StreetName       string             `json:"street_name"`
-	StreetNumber     string             `json:"street_number"`
-	Suburb           string             `json:"suburb"`
-}
-
-// CreateTenant defines model for CreateTenant.
-type CreateTenant struct {
-	Email             openapi_types.Email `json:"email"`
-	EndDate           openapi_types.Date  `json:"end_date"`
-	Frequency         string              `json:"frequency"`
-	Mobile            string              `json:"mobile"`
-	Name              string              `json:"name"`
-	OriginalStartDate openapi_types.Date  `json:"original_start_date"`
-	PaidTo            openapi_types.Date  `json:"paid_to"`
-	Phone             *string             `json:"phone,omitempty"`
-	PropertyId        openapi_types.UUID  `json:"property_id"`
-	RentalAmount      float64             `json:"rental_amount"`
-	StartDate         openapi_types.Date  `json:"start_date"`
-}
-
-// Error defines model for Error.
-type Error struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
-}
-
-// Landlord defines model for Landlord.
-type Landlord struct {
-	AddressLine1 string              `json:"address_line_1"`
-	AddressLine2 *string             `json:"address_line_2,omitempty"`
-	Country      string              `json:"country"`
-	CreatedAt    time.Time           `json:"created_at"`
-	Email        openapi_types.Email `json:"email"`
-	Id           *openapi_types.UUID `json:"id,omitempty"`
-	IsArchived   *time.Time          `json:"is_archived,omitempty"`
-	Mobile       string              `json:"mobile"`
-	Name         string              `json:"name"`
-	Phone        *string             `json:"phone,omitempty"`
-	Postcode     string              `json:"postcode"`
-	State        string              `json:"state"`
-	Suburb       string              `json:"suburb"`
-	UpdatedAt    time.Time           `json:"updated_at"`
-}
-
-// LandlordList defines model for LandlordList.
-type LandlordList struct {
-	Items      []Landlord        `json:"items"`
-	Pagination PaginatedMetadata `json:"pagination"`
-}
-
-// PaginatedMetadata defines model for PaginatedMetadata.
-type PaginatedMetadata struct {
-	Count       int32 `json:"count"`
-	CurrentPage int32 `json:"current_page"`
-	PerPage     int32 `json:"per_page"`
-	Total       int32 `json:"total"`
-	TotalPages  int32 `json:"total_pages"`
-}
-
-// Property defines model for Property.
-type Property struct {
-	Country          string              `json:"country"`
-	CreatedAt        time.Time           `json:"created_at"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
-	IsArchived       *time.Time          `json:"is_archived,omitempty"`
-	LandlordId       openapi_types.UUID  `json:"landlord_id"`
-	ManagementFee    float64             `json:"management_fee"`
-	ManagementGained openapi_types.Date  `json:"management_gained"`
-	ManagementLost   *openapi_types.Date `json:"management_lost,omitempty"`
-	Postcode         string              `json:"postcode"`
-	State            string              `json:"state"`
-	StreetName       string              `json:"street_name"`
-	StreetNumber     string              `json:"street_number"`
-	Suburb           string              `json:"suburb"`
-	UpdatedAt        time.Time           `json:"updated_at"`
-}
-
-// PropertyList defines model for PropertyList.
-type PropertyList struct {
-	Items      []Property        `json:"items"`
-	Pagination PaginatedMetadata `json:"pagination"`
-}
-
-// Tenant defines model for Tenant.
-type Tenant struct {
-	CreatedAt         time.Time           `json:"created_at"`
-	Email             openapi_types.Email `json:"email"`
-	EndDate           openapi_types.Date  `json:"end_date"`
-	Frequency         string              `json:"frequency"`
-	Id                *openapi_types.UUID `json:"id,omitempty"`
-	IsArchived        *time.Time          `json:"is_archived,omitempty"`
-	Mobile            string              `json:"mobile"`
-	Name              string              `json:"name"`
-	OriginalStartDate openapi_types.Date  `json:"original_start_date"`
-	PaidFrom          openapi_types.Date  `json:"paid_from"`
-	PaidTo            openapi_types.Date  `json:"paid_to"`
-	Phone             *string             `json:"phone,omitempty"`
-	PropertyId        openapi_types.UUID  `json:"property_id"`
-	RentalAmount      float64             `json:"rental_amount"`
-	StartDate         openapi_types.Date  `json:"start_date"`
-	TerminationDate   *openapi_types.Date `json:"termination_date,omitempty"`
-	TerminationReason *string             `json:"termination_reason,omitempty"`
-	UpdatedAt         time.Time           `json:"updated_at"`
-	VacateDate        *openapi_types.Date `json:"vacate_date,omitempty"`
-}
-
-// TenantList defines model for TenantList.
-type TenantList struct {
-	Items      []Tenant          `json:"items"`
-	Pagination PaginatedMetadata `json:"pagination"`
-}
-
-// UpdateLandlord defines model for UpdateLandlord.
-type UpdateLandlord struct {
-	AddressLine1 *string              `json:"address_line_1,omitempty"`
-	AddressLine2 *string              `json:"address_line_2,omitempty"`
-	Country      *string              `json:"country,omitempty"`
-	Email        *openapi_types.Email `json:"email,omitempty"`
-	IsArchived   *time.Time           `json:"is_archived"`
-	Mobile       *string              `json:"mobile,omitempty"`
-	Name         *string              `json:"name,omitempty"`
-	Phone        *string              `json:"phone,omitempty"`
-	Postcode     *string              `json:"postcode,omitempty"`
-	State        *string              `json:"state,omitempty"`
-	Suburb       *string              `json:"suburb,omitempty"`
-}
-
-// UpdateProperty defines model for UpdateProperty.
-type UpdateProperty struct {
-	Country          *string             `json:"country,omitempty"`
-	IsArchived       *time.Time          `json:"is_archived"`
-	ManagementFee    *float64            `json:"management_fee,omitempty"`
-	ManagementGained *openapi_types.Date `json:"management_gained,omitempty"`
-	ManagementLost   *openapi_types.Date `json:"management_lost"`
-	Postcode         *string             `json:"postcode,omitempty"`
-	State            *string             `json:"state,omitempty"`
-	StreetName       *string             `json:"street_name,omitempty"`
-	StreetNumber     *string             `json:"street_number,omitempty"`
-	Suburb           *string             `json:"suburb,omitempty"`
-}
-
-// UpdateTenant defines model for UpdateTenant.
-type UpdateTenant struct {
-	Email             *openapi_types.Email `json:"email,omitempty"`
-	EndDate           *openapi_types.Date  `json:"end_date,omitempty"`
-	Frequency         *string              `json:"frequency,omitempty"`
-	IsArchived        *time.Time           `json:"is_archived"`
-	Mobile            *string              `json:"mobile,omitempty"`
-	Name              *string              `json:"name,omitempty"`
-	OriginalStartDate *openapi_types.Date  `json:"original_start_date,omitempty"`
-	PaidFrom          *openapi_types.Date  `json:"paid_from,omitempty"`
-	PaidTo            *openapi_types.Date  `json:"paid_to,omitempty"`
-	Phone             *string              `json:"phone,omitempty"`
-	RentalAmount      *float64             `json:"rental_amount,omitempty"`
-	StartDate         *openapi_types.Date  `json:"start_date,omitempty"`
-	TerminationDate   *openapi_types.Date  `json:"termination_date"`
-	TerminationReason *string              `json:"termination_reason"`
-	VacateDate        *openapi_types.Date  `json:"vacate_date"`
-}
-
-// LandlordsListParams defines parameters for LandlordsList.
-type LandlordsListParams struct {
-	Page         *int32  `form:"page,omitempty" json:"page,omitempty"`
-	Limit        *int32  `form:"limit,omitempty" json:"limit,omitempty"`
-	Name         *string `form:"name,omitempty" json:"name,omitempty"`
-	ArchivedOnly *bool   `form:"archived_only,omitempty" json:"archived_only,omitempty"`
-}
-
-// PropertiesListParams defines parameters for PropertiesList.
-type PropertiesListParams struct {
-	Page         *int32  `form:"page,omitempty" json:"page,omitempty"`
-	Limit        *int32  `form:"limit,omitempty" json:"limit,omitempty"`
-	Address      *string `form:"address,omitempty" json:"address,omitempty"`
-	ArchivedOnly *bool   `form:"archived_only,omitempty" json:"archived_only,omitempty"`
-}
-
-// TenantsListParams defines parameters for TenantsList.
-type TenantsListParams struct {
-	Page         *int32  `form:"page,omitempty" json:"page,omitempty"`
-	Limit        *int32  `form:"limit,omitempty" json:"limit,omitempty"`
-	Name         *string `form:"name,omitempty" json:"name,omitempty"`
-	ArchivedOnly *bool   `form:"archived_only,omitempty" json:"archived_only,omitempty"`
-}
-
-// LandlordsCreateJSONRequestBody defines body for LandlordsCreate for application/json ContentType.
-type LandlordsCreateJSONRequestBody = CreateLandlord
-
-// LandlordsUpdateJSONRequestBody defines body for LandlordsUpdate for application/json ContentType.
-type LandlordsUpdateJSONRequestBody = UpdateLandlord
-
-// PropertiesCreateJSONRequestBody defines body for PropertiesCreate for application/json ContentType.
-type PropertiesCreateJSONRequestBody = CreateProperty
-
-// PropertiesUpdateJSONRequestBody defines body for PropertiesUpdate for application/json ContentType.
-type PropertiesUpdateJSONRequestBody = UpdateProperty
-
-// TenantsCreateJSONRequestBody defines body for TenantsCreate for application/json ContentType.
-type TenantsCreateJSONRequestBody = CreateTenant
-
-// TenantsUpdateJSONRequestBody defines body for TenantsUpdate for application/json ContentType.
-type TenantsUpdateJSONRequestBody = UpdateTenant
-
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
@@ -256,46 +27,118 @@ type ServerInterface interface {
 	LandlordsList(w http.ResponseWriter, r *http.Request, params LandlordsListParams)
 
 	// (POST /landlords)
-	LandlordsCreate(w http.ResponseWriter, r *http.Request)
+	LandlordsCreate(w http.ResponseWriter, r *http.Request, params LandlordsCreateParams)
+
+	// (POST /landlords/batch)
+	LandlordsBatch(w http.ResponseWriter, r *http.Request)
 
 	// (DELETE /landlords/{id})
-	LandlordsArchive(w http.ResponseWriter, r *http.Request, id string)
+	LandlordsArchive(w http.ResponseWriter, r *http.Request, id string, params LandlordsArchiveParams)
 
 	// (GET /landlords/{id})
-	LandlordsGet(w http.ResponseWriter, r *http.Request, id string)
+	LandlordsGet(w http.ResponseWriter, r *http.Request, id string, params LandlordsGetParams)
 
 	// (PATCH /landlords/{id})
-	LandlordsUpdate(w http.ResponseWriter, r *http.Request, id string)
+	LandlordsUpdate(w http.ResponseWriter, r *http.Request, id string, params LandlordsUpdateParams)
+
+	// (GET /landlords/{id}/properties)
+	LandlordsListProperties(w http.ResponseWriter, r *http.Request, id string, params LandlordsListPropertiesParams)
+
+	// (GET /maintenance-requests)
+	MaintenanceRequestsList(w http.ResponseWriter, r *http.Request, params MaintenanceRequestsListParams)
+
+	// (PATCH /maintenance-requests/{id})
+	MaintenanceRequestsUpdate(w http.ResponseWriter, r *http.Request, id string)
+
+	// (GET /organisation)
+	OrganisationSettingsGet(w http.ResponseWriter, r *http.Request)
+
+	// (PATCH /organisation)
+	OrganisationSettingsUpdate(w http.ResponseWriter, r *http.Request)
+
+	// (GET /portal-users)
+	PortalUsersList(w http.ResponseWriter, r *http.Request, params PortalUsersListParams)
+
+	// (POST /portal-users)
+	PortalUsersCreate(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /portal-users/{id})
+	PortalUsersRevoke(w http.ResponseWriter, r *http.Request, id string)
+
+	// (GET /portal/ledger)
+	PortalLedger(w http.ResponseWriter, r *http.Request, params PortalLedgerParams)
+
+	// (GET /portal/maintenance-requests)
+	PortalListMaintenanceRequests(w http.ResponseWriter, r *http.Request, params PortalListMaintenanceRequestsParams)
+
+	// (POST /portal/maintenance-requests)
+	PortalCreateMaintenanceRequest(w http.ResponseWriter, r *http.Request, params PortalCreateMaintenanceRequestParams)
+
+	// (GET /portal/me)
+	PortalMe(w http.ResponseWriter, r *http.Request, params PortalMeParams)
+
+	// (GET /portal/organisations)
+	PortalOrganisations(w http.ResponseWriter, r *http.Request)
+
+	// (GET /portal/properties)
+	PortalProperties(w http.ResponseWriter, r *http.Request, params PortalPropertiesParams)
+
+	// (GET /portal/statement)
+	PortalStatement(w http.ResponseWriter, r *http.Request, params PortalStatementParams)
+
+	// (GET /portal/tenancies)
+	PortalTenancies(w http.ResponseWriter, r *http.Request, params PortalTenanciesParams)
+
+	// (GET /portfolios/{user_id})
+	PortfoliosGet(w http.ResponseWriter, r *http.Request, userId string)
 
 	// (GET /properties)
 	PropertiesList(w http.ResponseWriter, r *http.Request, params PropertiesListParams)
 
 	// (POST /properties)
-	PropertiesCreate(w http.ResponseWriter, r *http.Request)
+	PropertiesCreate(w http.ResponseWriter, r *http.Request, params PropertiesCreateParams)
+
+	// (POST /properties/batch)
+	PropertiesBatch(w http.ResponseWriter, r *http.Request)
 
 	// (DELETE /properties/{id})
-	PropertiesArchive(w http.ResponseWriter, r *http.Request, id string)
+	PropertiesArchive(w http.ResponseWriter, r *http.Request, id string, params PropertiesArchiveParams)
 
 	// (GET /properties/{id})
-	PropertiesGet(w http.ResponseWriter, r *http.Request, id string)
+	PropertiesGet(w http.ResponseWriter, r *http.Request, id string, params PropertiesGetParams)
 
 	// (PATCH /properties/{id})
-	PropertiesUpdate(w http.ResponseWriter, r *http.Request, id string)
+	PropertiesUpdate(w http.ResponseWriter, r *http.Request, id string, params PropertiesUpdateParams)
+
+	// (GET /properties/{id}/tenants)
+	PropertiesListTenants(w http.ResponseWriter, r *http.Request, id string, params PropertiesListTenantsParams)
+
+	// (GET /search)
+	SearchQuery(w http.ResponseWriter, r *http.Request, params SearchQueryParams)
 
 	// (GET /tenants)
 	TenantsList(w http.ResponseWriter, r *http.Request, params TenantsListParams)
 
 	// (POST /tenants)
-	TenantsCreate(w http.ResponseWriter, r *http.Request)
+	TenantsCreate(w http.ResponseWriter, r *http.Request, params TenantsCreateParams)
+
+	// (POST /tenants/batch)
+	TenantsBatch(w http.ResponseWriter, r *http.Request)
 
 	// (DELETE /tenants/{id})
-	TenantsArchive(w http.ResponseWriter, r *http.Request, id string)
+	TenantsArchive(w http.ResponseWriter, r *http.Request, id string, params TenantsArchiveParams)
 
 	// (GET /tenants/{id})
-	TenantsGet(w http.ResponseWriter, r *http.Request, id string)
+	TenantsGet(w http.ResponseWriter, r *http.Request, id string, params TenantsGetParams)
 
 	// (PATCH /tenants/{id})
-	TenantsUpdate(w http.ResponseWriter, r *http.Request, id string)
+	TenantsUpdate(w http.ResponseWriter, r *http.Request, id string, params TenantsUpdateParams)
+
+	// (GET /tenants/{id}/payments)
+	TenantsListPayments(w http.ResponseWriter, r *http.Request, id string)
+
+	// (POST /tenants/{id}/payments)
+	TenantsCreatePayment(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -337,6 +180,38 @@ func (siw *ServerInterfaceWrapper) LandlordsList(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "name" -------------
 
 	err = runtime.BindQueryParameter("form", false, false, "name", r.URL.Query(), &params.Name)
@@ -353,6 +228,62 @@ func (siw *ServerInterfaceWrapper) LandlordsList(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "suburb" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "suburb", r.URL.Query(), &params.Suburb)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "suburb", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.LandlordsList(w, r, params)
 	}))
@@ -367,6 +298,52 @@ func (siw *ServerInterfaceWrapper) LandlordsList(w http.ResponseWriter, r *http.
 // LandlordsCreate operation middleware
 func (siw *ServerInterfaceWrapper) LandlordsCreate(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LandlordsCreateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LandlordsCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LandlordsBatch operation middleware
+func (siw *ServerInterfaceWrapper) LandlordsBatch(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -374,7 +351,7 @@ func (siw *ServerInterfaceWrapper) LandlordsCreate(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LandlordsCreate(w, r)
+		siw.Handler.LandlordsBatch(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -404,8 +381,32 @@ func (siw *ServerInterfaceWrapper) LandlordsArchive(w http.ResponseWriter, r *ht
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LandlordsArchiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LandlordsArchive(w, r, id)
+		siw.Handler.LandlordsArchive(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -435,8 +436,59 @@ func (siw *ServerInterfaceWrapper) LandlordsGet(w http.ResponseWriter, r *http.R
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LandlordsGetParams
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LandlordsGet(w, r, id)
+		siw.Handler.LandlordsGet(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -456,18 +508,1416 @@ func (siw *ServerInterfaceWrapper) LandlordsUpdate(w http.ResponseWriter, r *htt
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LandlordsUpdateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LandlordsUpdate(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LandlordsListProperties operation middleware
+func (siw *ServerInterfaceWrapper) LandlordsListProperties(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LandlordsListPropertiesParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "archived_only" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "archived_only", r.URL.Query(), &params.ArchivedOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived_only", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LandlordsListProperties(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MaintenanceRequestsList operation middleware
+func (siw *ServerInterfaceWrapper) MaintenanceRequestsList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MaintenanceRequestsListParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MaintenanceRequestsList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MaintenanceRequestsUpdate operation middleware
+func (siw *ServerInterfaceWrapper) MaintenanceRequestsUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MaintenanceRequestsUpdate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// OrganisationSettingsGet operation middleware
+func (siw *ServerInterfaceWrapper) OrganisationSettingsGet(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.OrganisationSettingsGet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// OrganisationSettingsUpdate operation middleware
+func (siw *ServerInterfaceWrapper) OrganisationSettingsUpdate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.OrganisationSettingsUpdate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalUsersList operation middleware
+func (siw *ServerInterfaceWrapper) PortalUsersList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalUsersListParams
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalUsersList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalUsersCreate operation middleware
+func (siw *ServerInterfaceWrapper) PortalUsersCreate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalUsersCreate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalUsersRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PortalUsersRevoke(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalUsersRevoke(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalLedger operation middleware
+func (siw *ServerInterfaceWrapper) PortalLedger(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalLedgerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalLedger(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalListMaintenanceRequests operation middleware
+func (siw *ServerInterfaceWrapper) PortalListMaintenanceRequests(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalListMaintenanceRequestsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalListMaintenanceRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalCreateMaintenanceRequest operation middleware
+func (siw *ServerInterfaceWrapper) PortalCreateMaintenanceRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalCreateMaintenanceRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalCreateMaintenanceRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalMe operation middleware
+func (siw *ServerInterfaceWrapper) PortalMe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalMeParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalMe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalOrganisations operation middleware
+func (siw *ServerInterfaceWrapper) PortalOrganisations(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalOrganisations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalProperties operation middleware
+func (siw *ServerInterfaceWrapper) PortalProperties(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalPropertiesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalProperties(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalStatement operation middleware
+func (siw *ServerInterfaceWrapper) PortalStatement(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalStatementParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalStatement(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortalTenancies operation middleware
+func (siw *ServerInterfaceWrapper) PortalTenancies(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PortalTenanciesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Portal-Organisation" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Portal-Organisation")]; found {
+		var PortalOrganisation string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Portal-Organisation", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Portal-Organisation", valueList[0], &PortalOrganisation, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Portal-Organisation", Err: err})
+			return
+		}
+
+		params.PortalOrganisation = &PortalOrganisation
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortalTenancies(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PortfoliosGet operation middleware
+func (siw *ServerInterfaceWrapper) PortfoliosGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", mux.Vars(r)["user_id"], &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PortfoliosGet(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesList operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesListParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "address" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "address", r.URL.Query(), &params.Address)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "address", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "archived_only" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "archived_only", r.URL.Query(), &params.ArchivedOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived_only", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "assigned_to" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "assigned_to", r.URL.Query(), &params.AssignedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "assigned_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "landlord_id" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "landlord_id", r.URL.Query(), &params.LandlordId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "landlord_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "suburb" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "suburb", r.URL.Query(), &params.Suburb)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "suburb", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "postcode" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "postcode", r.URL.Query(), &params.Postcode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postcode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "management_gained_from" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "management_gained_from", r.URL.Query(), &params.ManagementGainedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "management_gained_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "management_gained_to" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "management_gained_to", r.URL.Query(), &params.ManagementGainedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "management_gained_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "management_fee_min" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "management_fee_min", r.URL.Query(), &params.ManagementFeeMin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "management_fee_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "management_fee_max" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "management_fee_max", r.URL.Query(), &params.ManagementFeeMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "management_fee_max", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "expand", r.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expand", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesCreate operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesCreate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesCreateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesBatch operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesBatch(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesArchive operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesArchive(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesArchiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesArchive(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesGet operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesGetParams
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "expand", r.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expand", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesGet(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesUpdateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesUpdate(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PropertiesListTenants operation middleware
+func (siw *ServerInterfaceWrapper) PropertiesListTenants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PropertiesListTenantsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "archived_only" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "archived_only", r.URL.Query(), &params.ArchivedOnly)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived_only", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PropertiesListTenants(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchQuery operation middleware
+func (siw *ServerInterfaceWrapper) SearchQuery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchQueryParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
 		return
 	}
 
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "limit" -------------
 
-	r = r.WithContext(ctx)
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LandlordsUpdate(w, r, id)
+		siw.Handler.SearchQuery(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -477,8 +1927,8 @@ func (siw *ServerInterfaceWrapper) LandlordsUpdate(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// PropertiesList operation middleware
-func (siw *ServerInterfaceWrapper) PropertiesList(w http.ResponseWriter, r *http.Request) {
+// TenantsList operation middleware
+func (siw *ServerInterfaceWrapper) TenantsList(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -489,7 +1939,7 @@ func (siw *ServerInterfaceWrapper) PropertiesList(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PropertiesListParams
+	var params TenantsListParams
 
 	// ------------- Optional query parameter "page" -------------
 
@@ -507,11 +1957,43 @@ func (siw *ServerInterfaceWrapper) PropertiesList(w http.ResponseWriter, r *http
 		return
 	}
 
-	// ------------- Optional query parameter "address" -------------
+	// ------------- Optional query parameter "after" -------------
 
-	err = runtime.BindQueryParameter("form", false, false, "address", r.URL.Query(), &params.Address)
+	err = runtime.BindQueryParameter("form", false, false, "after", r.URL.Query(), &params.After)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "address", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
@@ -523,59 +2005,128 @@ func (siw *ServerInterfaceWrapper) PropertiesList(w http.ResponseWriter, r *http
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PropertiesList(w, r, params)
-	}))
+	// ------------- Optional query parameter "assigned_to" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", false, false, "assigned_to", r.URL.Query(), &params.AssignedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "assigned_to", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "property_id" -------------
 
-// PropertiesCreate operation middleware
-func (siw *ServerInterfaceWrapper) PropertiesCreate(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", false, false, "property_id", r.URL.Query(), &params.PropertyId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "property_id", Err: err})
+		return
+	}
 
-	ctx := r.Context()
+	// ------------- Optional query parameter "frequency" -------------
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	err = runtime.BindQueryParameter("form", false, false, "frequency", r.URL.Query(), &params.Frequency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "frequency", Err: err})
+		return
+	}
 
-	r = r.WithContext(ctx)
+	// ------------- Optional query parameter "paid_to_from" -------------
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PropertiesCreate(w, r)
-	}))
+	err = runtime.BindQueryParameter("form", false, false, "paid_to_from", r.URL.Query(), &params.PaidToFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paid_to_from", Err: err})
+		return
+	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "paid_to_to" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "paid_to_to", r.URL.Query(), &params.PaidToTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paid_to_to", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "end_date_from" -------------
 
-// PropertiesArchive operation middleware
-func (siw *ServerInterfaceWrapper) PropertiesArchive(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", false, false, "end_date_from", r.URL.Query(), &params.EndDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date_from", Err: err})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "end_date_to" -------------
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	err = runtime.BindQueryParameter("form", false, false, "end_date_to", r.URL.Query(), &params.EndDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "end_date_to", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Optional query parameter "rental_amount_min" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "rental_amount_min", r.URL.Query(), &params.RentalAmountMin)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rental_amount_min", Err: err})
 		return
 	}
 
-	ctx := r.Context()
+	// ------------- Optional query parameter "rental_amount_max" -------------
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	err = runtime.BindQueryParameter("form", false, false, "rental_amount_max", r.URL.Query(), &params.RentalAmountMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rental_amount_max", Err: err})
+		return
+	}
 
-	r = r.WithContext(ctx)
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "expand", r.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expand", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PropertiesArchive(w, r, id)
+		siw.Handler.TenantsList(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -585,20 +2136,55 @@ func (siw *ServerInterfaceWrapper) PropertiesArchive(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// PropertiesGet operation middleware
-func (siw *ServerInterfaceWrapper) PropertiesGet(w http.ResponseWriter, r *http.Request) {
+// TenantsCreate operation middleware
+func (siw *ServerInterfaceWrapper) TenantsCreate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	ctx := r.Context()
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TenantsCreateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TenantsCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// TenantsBatch operation middleware
+func (siw *ServerInterfaceWrapper) TenantsBatch(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -606,7 +2192,7 @@ func (siw *ServerInterfaceWrapper) PropertiesGet(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PropertiesGet(w, r, id)
+		siw.Handler.TenantsBatch(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -616,8 +2202,8 @@ func (siw *ServerInterfaceWrapper) PropertiesGet(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// PropertiesUpdate operation middleware
-func (siw *ServerInterfaceWrapper) PropertiesUpdate(w http.ResponseWriter, r *http.Request) {
+// TenantsArchive operation middleware
+func (siw *ServerInterfaceWrapper) TenantsArchive(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -636,8 +2222,32 @@ func (siw *ServerInterfaceWrapper) PropertiesUpdate(w http.ResponseWriter, r *ht
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TenantsArchiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PropertiesUpdate(w, r, id)
+		siw.Handler.TenantsArchive(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -647,11 +2257,20 @@ func (siw *ServerInterfaceWrapper) PropertiesUpdate(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// TenantsList operation middleware
-func (siw *ServerInterfaceWrapper) TenantsList(w http.ResponseWriter, r *http.Request) {
+// TenantsGet operation middleware
+func (siw *ServerInterfaceWrapper) TenantsGet(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -659,62 +2278,66 @@ func (siw *ServerInterfaceWrapper) TenantsList(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params TenantsListParams
+	var params TenantsGetParams
 
-	// ------------- Optional query parameter "page" -------------
+	// ------------- Optional query parameter "expand" -------------
 
-	err = runtime.BindQueryParameter("form", false, false, "page", r.URL.Query(), &params.Page)
+	err = runtime.BindQueryParameter("form", false, false, "expand", r.URL.Query(), &params.Expand)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expand", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "fields" -------------
 
-	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", false, false, "fields", r.URL.Query(), &params.Fields)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "name" -------------
-
-	err = runtime.BindQueryParameter("form", false, false, "name", r.URL.Query(), &params.Name)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
+	headers := r.Header
 
-	// ------------- Optional query parameter "archived_only" -------------
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
 
-	err = runtime.BindQueryParameter("form", false, false, "archived_only", r.URL.Query(), &params.ArchivedOnly)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "archived_only", Err: err})
-		return
-	}
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TenantsList(w, r, params)
-	}))
+		params.IfNoneMatch = &IfNoneMatch
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// TenantsCreate operation middleware
-func (siw *ServerInterfaceWrapper) TenantsCreate(w http.ResponseWriter, r *http.Request) {
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Modified-Since", Count: n})
+			return
+		}
 
-	ctx := r.Context()
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Modified-Since", Err: err})
+			return
+		}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+		params.IfModifiedSince = &IfModifiedSince
 
-	r = r.WithContext(ctx)
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TenantsCreate(w, r)
+		siw.Handler.TenantsGet(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -724,8 +2347,8 @@ func (siw *ServerInterfaceWrapper) TenantsCreate(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// TenantsArchive operation middleware
-func (siw *ServerInterfaceWrapper) TenantsArchive(w http.ResponseWriter, r *http.Request) {
+// TenantsUpdate operation middleware
+func (siw *ServerInterfaceWrapper) TenantsUpdate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -744,8 +2367,32 @@ func (siw *ServerInterfaceWrapper) TenantsArchive(w http.ResponseWriter, r *http
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TenantsUpdateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TenantsArchive(w, r, id)
+		siw.Handler.TenantsUpdate(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -755,8 +2402,8 @@ func (siw *ServerInterfaceWrapper) TenantsArchive(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// TenantsGet operation middleware
-func (siw *ServerInterfaceWrapper) TenantsGet(w http.ResponseWriter, r *http.Request) {
+// TenantsListPayments operation middleware
+func (siw *ServerInterfaceWrapper) TenantsListPayments(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -776,7 +2423,7 @@ func (siw *ServerInterfaceWrapper) TenantsGet(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TenantsGet(w, r, id)
+		siw.Handler.TenantsListPayments(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -786,8 +2433,8 @@ func (siw *ServerInterfaceWrapper) TenantsGet(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// TenantsUpdate operation middleware
-func (siw *ServerInterfaceWrapper) TenantsUpdate(w http.ResponseWriter, r *http.Request) {
+// TenantsCreatePayment operation middleware
+func (siw *ServerInterfaceWrapper) TenantsCreatePayment(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -807,7 +2454,7 @@ func (siw *ServerInterfaceWrapper) TenantsUpdate(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.TenantsUpdate(w, r, id)
+		siw.Handler.TenantsCreatePayment(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -934,65 +2581,234 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/landlords", wrapper.LandlordsCreate).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/landlords/batch", wrapper.LandlordsBatch).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/landlords/{id}", wrapper.LandlordsArchive).Methods("DELETE")
 
 	r.HandleFunc(options.BaseURL+"/landlords/{id}", wrapper.LandlordsGet).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/landlords/{id}", wrapper.LandlordsUpdate).Methods("PATCH")
 
+	r.HandleFunc(options.BaseURL+"/landlords/{id}/properties", wrapper.LandlordsListProperties).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/maintenance-requests", wrapper.MaintenanceRequestsList).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/maintenance-requests/{id}", wrapper.MaintenanceRequestsUpdate).Methods("PATCH")
+
+	r.HandleFunc(options.BaseURL+"/organisation", wrapper.OrganisationSettingsGet).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/organisation", wrapper.OrganisationSettingsUpdate).Methods("PATCH")
+
+	r.HandleFunc(options.BaseURL+"/portal-users", wrapper.PortalUsersList).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal-users", wrapper.PortalUsersCreate).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/portal-users/{id}", wrapper.PortalUsersRevoke).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/portal/ledger", wrapper.PortalLedger).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/maintenance-requests", wrapper.PortalListMaintenanceRequests).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/maintenance-requests", wrapper.PortalCreateMaintenanceRequest).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/portal/me", wrapper.PortalMe).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/organisations", wrapper.PortalOrganisations).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/properties", wrapper.PortalProperties).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/statement", wrapper.PortalStatement).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portal/tenancies", wrapper.PortalTenancies).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/portfolios/{user_id}", wrapper.PortfoliosGet).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/properties", wrapper.PropertiesList).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/properties", wrapper.PropertiesCreate).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/properties/batch", wrapper.PropertiesBatch).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/properties/{id}", wrapper.PropertiesArchive).Methods("DELETE")
 
 	r.HandleFunc(options.BaseURL+"/properties/{id}", wrapper.PropertiesGet).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/properties/{id}", wrapper.PropertiesUpdate).Methods("PATCH")
 
+	r.HandleFunc(options.BaseURL+"/properties/{id}/tenants", wrapper.PropertiesListTenants).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/search", wrapper.SearchQuery).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/tenants", wrapper.TenantsList).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/tenants", wrapper.TenantsCreate).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/tenants/batch", wrapper.TenantsBatch).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/tenants/{id}", wrapper.TenantsArchive).Methods("DELETE")
 
 	r.HandleFunc(options.BaseURL+"/tenants/{id}", wrapper.TenantsGet).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/tenants/{id}", wrapper.TenantsUpdate).Methods("PATCH")
 
+	r.HandleFunc(options.BaseURL+"/tenants/{id}/payments", wrapper.TenantsListPayments).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/tenants/{id}/payments", wrapper.TenantsCreatePayment).Methods("POST")
+
 	return r
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xc247bOBL9FYG7j07buSwQ+C3ZXQwGSDANJPMUNARaLNsMKFIhS554Gv73AUlZF1uy",
-	"JcftSGm9dYu3YtWpwzqUuh9JpOJESZBoyPyRmGgNMXU//lcDRfhAJRNKM/sk0SoBjRxcO2VMgzGh4BLC",
-	"l/YJbhMgc2JQc7kiu0m1y6vaLpFKJeptbRvElAvbslQ6pkjm2ZPJcddYLbiA2lkkjesbkrWSDS3KYKRY",
-	"faNBig0t6SLVi5qm3YRo+JZyDYzMv3iLJvlmMtsnhx7NJywZtF++cNxD7g21+AoRWkN86O59uLbHoTvl",
-	"dJEFPOSs4vo05azW81TSFcQgMVwCVIYwlS7cxrJBMo0XoA8GrSiXUF2K+R1eLSyoATBsxMG+3Vv3I4Et",
-	"++5w3qod7WJ75N06zzUD4DNIKvE4/B3yCiQLWebYs/FZWl+AjOqBdUmKKs1XXFIRGqQa21uSUM5CVO36",
-	"NtNAlkBtU0GDRCpCGtvwtcyEThs7gFvZvkkjq+x9cWhfOV71nq5YV8JCHeL+r7XSdUzDqhvjEl+/KnbG",
-	"JcIq4wQwhq7gfJZlybLvX2fNzzy1Ipd7LKR4FNEXyONaDHbIyHosaqDsDym2ZI46hbphJqQ6WvNNDdc2",
-	"mtXvU3VC0oR1dPUBlE4mzkXHcSX+FQtP4fQDNzU8zRHi6g//1rAkc/KvaVG1TbOSbZpjfpcvRLWmW8+H",
-	"NrmRK3lulnvfE9hHQMoo0mOfOWMqc9Zt7Xii+jKkJTtEqbb0FSYZRbQYkoDu0h0VUtGlr5vctBpx4EK/",
-	"VAYaUrL0YJ/VhWq9fFGNdwlF3ZJ3elyDlkYJZXCgdeu1qPOnFLzdKHafIdeg2Dzb+kGxTfX9E1cgV9QE",
-	"fS9mfkh/LLWKn7NamRAEHWcAvmyQBmp8Ql2BwCZkQyOKcKHacl7sILlc+J9CfnXjP08S12C/jG76wX1/",
-	"ul0P5HayDV/JVAhq066J9Hp/xdkQo8tK1Ou4rFd14Vlze3m/2RDVft0t/rQEG1KB0JMD/2wc6guAs8PO",
-	"HO5nxh8j3e4eolRz3H6yp5fH93ugGvS7FNf56zI7yD8uXLJGTMjOzsHl0gUOOdrFcz0SfMwZI/gEesMj",
-	"a+UGtHHHKJndze5mDmIJSJpwMiev3SMLB1w7Y6Z7/eV+W4GLqp3eOe93Rub5DZNxFYAdq2kMCNqQ+ZdH",
-	"At8T4RhnSYUBay2Zk28pOC3mUU+yqwh/hLe87mg3s+Axx6eZei8685mP4t1unj2thMoKkpoJF0oJoJLs",
-	"dg82xUyipPFQeTWb+VNOIviEo0kieOSCM/2a4bqYsM3lnouiwxUDE2me+KKLfF5D4BjSYLCmJjBpFAEw",
-	"YHcWQv+5oiX+or/GBAti0AFk7UX2OKCV8+bLg/UV0pXFYHFX/5AdgSdA7N9rEV8xgsH3im2vtrODF967",
-	"amVqWWN3FOGXV49wp+gGVLKABhL+CjQYleoIXIcFgAwylRBQE1DbnAp0aHhzCzRYk41HRKRSwQKpMEgl",
-	"A23QGo2lLbEUAlQBlxsqOAvMViL9Pijg7iYlLp4+craz1jAQ4A+jBji/8+RyTMuOiSzNF0SU3YyU4XiK",
-	"3m5BRt2J6M0VE6Yxnu+iCIwJuAlSSVNcK83/ztd/c1voU2lxv+RVxAPL03Vg/Hy6xvgNcMTyiOWh1BoU",
-	"o/UJNHuh+2SAvn4Bc3An1qqA6UcmDaYgGZN+0Elv67TqfVXtgXafd3lmqjm7Ev+FhHPl9e+vJZzzi/Vm",
-	"4VwA+QbKuXg7flvlXF13VM6DQm6Vkc9K5wLQA9XOF4F1LD0GWnpUKPpMqTFA8TyC+dmCuUE8F3AeqHru",
-	"VsT0I5VG9Txm/a1qNXTffTRLZ/9dyPi2edCiufTN4K8lmbOvlpoFcwbfG6jl/deUt9XK5VVHpTwgwJa4",
-	"96xIzkA8UIV8AUTH4mKgxUWJj09VEwOUxiOKnyWKG2RxhuOBauIupUofMmjUw2Oy36Aic2PsJD6Jq9Pf",
-	"a8XSyP4S+JXIhKRaZJ+Gm/l0/9Zj+6L405G7pdjeMdgQqxer831QERXB/2ADQiX5R+MH086nU2H7rZXB",
-	"+dvZ2xkpWf64p5bSPwrIn5X+sjV/tk/Ah90/AQAA//9ZR/qqJkoAAA==",
+	"H4sIAAAAAAAC/+x9e2/cuLLnVyH6XiB3AbnjeJLdew0sFs4kZ8c4yUk2TjALHGTbbKm6mxOJ7CEp233G",
+	"/u4LPvRqUS2qH36F/5wzcUtUsVis+tWDxb9GMcuWjAKVYnT610jEC8iw/s+zOdB4pf4rARFzspSE0dHp",
+	"6OsCUIYpnhM6R1g/9EKgBCQmqUAzzjJEpECMzzElAqu3kAApCZ2LCM0YR2LBrtXL6geJJWTq6wjTBBGK",
+	"5ALQknGJ01E0WnK2BC4JaIrwlLbJOcuF5DglmKK3uSAUhED/yLMp8FE0kqsljE5HQnJC56O7aDTlmCbq",
+	"v0//Gv07h9nodPRvLysWvLTzf/m2eO4uGqUkBhrDhJpRT/9qDys5Vk9PKM7A8cBdNOLwZ044JKPTf1ZE",
+	"fC8pZNM/IJZqqLdYxoszHi/IFThmSxE2vyleYTRVT7cYRRL1vzPGMyxHp6M8J4mLGWQ2yfT7rc98oumq",
+	"/JBaEQ4x4wkiM0TkC4GwRHJBBLoCLgijUbXs77/i+SjqYQBJ3FOvLU5zQimbs0nO0+a0OHHNaslJhvlq",
+	"ErOU5bw9t9/gBpnfUC4gcUghZZLMSKxFV0QIxvMx+rdXZydvf/nVObXWRH7lgCV8wDRJGU/a08FJwkGI",
+	"SUooTF45JarxyInzkZjlVPKV8zfIMGlyy/zFwa+MTUkKzlE6xDkaLReMdvzChIxZ4v5R89n9Sz7N+bR/",
+	"62iKonIylvZonaPlgDWCis9XjPveuXQfMaESKKYxfIE/cxCyvYgNqXLpBCJTD2VgHosaw3UT9hmvlJw6",
+	"RCpTk2qseMLyqR7ajmX1l1okTJKJZG7dnuCVUcLmU+r/hf6DZohEko3RuURZLiSaAsIzCbz2+wuB4pxz",
+	"86b+TIQE0w8InFXDxpi+0AMY1QIJktckhvEoqs0Ayxr9FWs5xECuIJkY1ns8PwMONPZYDsvH5jcqjm1Y",
+	"GW2zvglwqJz3NziW6QoxCojNUGoVw4QkiHHLN/WPgqdzcgW0pdVrr3mp93Jcr6dzAdw+u5lDxYMbWGHI",
+	"XjmkVAgyp+AWPsU7dP5OcUjLnx3FgA3giINYMirINAUNI+oPuWa0ST8O5aWhQQnuZAYOu/wOZjhPpUDS",
+	"iHod/Wh0pH9G1TBIDRP5bNbap+eYUEi8RH5bPSw5gJx0Kv7i924o5KvJ6yuwPm6TDj9l7mJUt4h+1Xuj",
+	"LaAD7CbQZJJYPvYux0xNvcDTezHBjJM5oTidCIm59Kekpvv7n+0283bf+e4fDlTidDLISg2a2Jp01emL",
+	"OlFDwYt1+urr5eZ0g7qaLDglLueC8Y8gcYIlbusO87sodRpO/sCxsZ9zEGP02bha05UyrIJxlDAQyniK",
+	"H2SpDAiHJWBpDalA1wswblRKhETxAtM5CDQFeQ1AETd4Roxb9oXCjWxT9wVkzqlBAOoJTZX5xhILBaCx",
+	"QJcaBVxGCE+FopxZArAwz7txOlwRlovNnyyecn92CjPGofXdGeGdH3bh9fecM95WBoX+LEWPUPnLSTUk",
+	"oRLmRlZBjTApXsBp+mk2Ov3nZh9Tf/VX9crd92iNBR9xvCAUjjjgBCuDpz+A9AeUOiGQJm74ZtcX6UeQ",
+	"XGAFtbSjIxd2mEj5cZg6jWYGQuC5e9PboSfE8enKbtunIoRTwRDXawmla/9/jyygPjp/hxaAE5efvraZ",
+	"rcav8bii83vXcv5q1wJonqlBCL3CKUkmlrxRVP5Fq4ic4lwuGCf/gsTY5SlJEo3CKJOTGctpoq0NnaUk",
+	"Vq/rl7WBn8wwSfVrhErgSlVoWkcVy2JMY0jNQ5JkwHI1BMcSJinJiNQ/LNUOpgmpj/ndsUa/kfkiJfOF",
+	"vIB54Q00V+Mz5lKtB0aL4llI0BVOcxgjJSXCvCnQH0wtDNOQUy/Q9YKlYB9tqQgdLgDH8v++ALnQfgBR",
+	"W9V8XS7sQMi8Z3b0nznwmuhNGUsBUwNYjQLqcZjUU1FJiksAHtLzjjW+SCZYtqzWkVp5J5TwRx1ue8sB",
+	"JypmMzqVPAfXa2JiwzmJP1mPOzIQjfJlMpDV7ThUtN+QQmP9GxRuktO3HVG4JXAThFKGrQDMIkI8p0qf",
+	"Mp4AP0XmiyJSu4si88niX3bRxRidmVih8rvRAl8BwhJlTEj05vgYsfJL7T2Pq1gkkZCJ3tBpPYCp/Zeb",
+	"c/Pem+PjkgmYc7xSP2PJMhKbyWsnqZDhDgXDEF4u0xWCK+CrinCFg6j1r+UCsjH6Xc1f/WWmA9NK9RgO",
+	"cAsviBSFPbwmcoEutcm8RCmLsVSIi0gb/Ls0LP7nyfexFpPLMfqkqLkmAhDgeFGjgwhNIDGRRfUNdk1N",
+	"cFsKxEEoP1ComMq1nRKRSORxDJBAMnYqRvN57xVYCz/2r4GRGe/xG2L7zbzb95G7PvH/ojnThWk015RB",
+	"o3VWd0fAoUB0A6CYA4b9vjCxsOqb1iwrpS3x3E1usVNf1APkjKPz2dFHS21nQMKf5mp9v0cbiIgqf6Ca",
+	"RSlvhe51wXA1ivlt7e1rlqeJUSIlvKtkfRT1A+Y1PWwp+O4nI2KTkAgDe7RURAXo1IFHrS2V16DpLBXe",
+	"zvrOJcV37T02cA97jmrF0c9JqVbd7/ld1EIXyesrX5JUzqVkVUlAVK5Jr4R8K0lu5c3MYJuUhnWV++Zp",
+	"vlFXr/vJt1kCZW3z7jHhFpXT28TEv7kdy7OKIutXMkRonOaJZahYYi7A/ChAKtZiKYGrl//ff5DkluIM",
+	"brXxvDUI61Yjxdsmymr+8+TWQK7bAnDdarh1a8HWbQ3U3law67YCXf/t313LUMz1A3GldUyMpd/GNoM6",
+	"d1G1RwZtFtemXuI5obhILPlZg8/mHUgqklpm4TOeAzLxNZPolEziVESIZURKSIrwSjPc1BYmPb+o5NQm",
+	"ebpgXHbKVClKgnFZ4Vs0XUVoyWFGbiCx0OzosnyOUKQGAp0qNlq9KW5H/+s/atJmRchITilIW4jLRZEk",
+	"7jI/VKIiaaTMIa7jgErGzH5ZAicsiRBLExOs4ToksWaJygqMTStv6zTu9IJwd83G+cUn9Prk1f9AxSM6",
+	"klT46CbqKdxxa5Z5hYqHSX/JSwUYXVtgcK7LL6CtBX7STOYIz3C0eZeCrEWx+8QgBSHWkj5qu11DUiSK",
+	"iomOIn8aiuG9CN+YdtHLq7kXFdJWE6Oo3Opr3+1ipINJLuXgk13fJozSl5HfMnYyNNdR4elNW6DGhAvz",
+	"wuCUbVeFwb4iI80kiqtQoZzssLhHWwDctniYUmmP2gs/zbh+JA4wZVn1cplv2YNV0ytwazg+xIK1Ra0W",
+	"F2dLoDpyPVlyNucg9GKybJmCiUpXoWtXLPpTLdHtwLWuIkBTdjVdIQ5LxqWINtZfPZYqwG0U0lCD7FZq",
+	"Oio2IVQsIVavT9Rq8iuctoc1U1Z2PWNULmpZP5ZLQgFVowhVxfNCoB+wlDo6Uf2E1PyTPCV0rqIHJF4g",
+	"ohOO05ykEq1A6pIeyuRCCSsHnCiveuzj+VcT6iupOLNRNMkQhes6gtIwlbI1w6oib0XhjIcpNTq2V/n7",
+	"FH8SV83PRT4t/4kkMauyXhoSqU2htoL6YZliqQj3sUlK4v5lI+1rknX2jzOkfkbqd0RxBjaKWe6Rlxer",
+	"hIIz/ddTyLovy1KS30Ab5ba0PB1mVNoekCOhu1590C2mhi45WdpsqMcrS+BDHtdYacizenDh9cYa082n",
+	"bJpiVKN0bZ7ND7m5vHL7QF8awFeHJ7AtbYuMs7kA6/YgYmoDldppq/chJSLbKGVPcKVrQwr3p43zTYmB",
+	"qpUsZqIzLvov6yWR6BoL85hkyJQs1CssfSoevSo27ddI9bGKkgHf2md15TBI61QV5fvRxuJM68pUJT01",
+	"2dggyPsAnnaoHdCmKR49T4BK4qybHBgJqGcTfKNQdbvkLgSNRpyl0DemmcsX9WS5/H3v2HK8bcpQ24Rb",
+	"MkuPtpvhm8HrGW0eYlHbSH0VLdR21gMgHMcKapJ2te6B2DnooEkHZ/wYsped0Wbzrpuku7h4U1WGr9J/",
+	"7FWzeyqW7Wfv/la/XLBdV/6L3S2F51oLnVkt43JQzbtawbgcsTNjNeNVLUVPJVoyQQxAV546y+XaCQdG",
+	"JY5lceqttfn3WBvsKbndBTl1HLPf6uDHVgOsavJiLGHLmmFHzMtuKTfA2FQz7F8d3JDP/e06O+DOm859",
+	"oOUDoT8EwsYcrkWyqyMtxalBuYCVrjsSAD1HPPcH6Ps9+8MepxnsJw/APvqTFQAa5CWXq7o/WVOj7UXQ",
+	"BoRYLf5STNhHaNUyc0hUVZE9YylhF3mmDpy2eYljSa5gYgwM8fTco1EKWICYwM2ScBukXOOFHheV45op",
+	"Y6RfRHaehFYl+//9WPmIwi8+15yDxwslHRNCJ5hzwFz4El2eE9TeKlIcLz1kluCVH8ndG8eYBBXeGDar",
+	"TmejNo5r7Ki96B0Maq+zc288/qN096iyt6pi3ntlXcGoF6JWZGerYQr1AzdLTJP/Wfx+2ZrdXs4gHuz0",
+	"YO2tlAn5RE8c7itafdCjiuYcaHuZBpp1K5NeleSVIgml5KGU3KOUvO7C77eUvCG3O5SSN8Z5RqXkNVvj",
+	"XUq+rGEGz+LBcn03GrwnXkrukJFHVkrukuLdS8k9R31MpeReJO+/lNylje6hlLyuXvdeSl5i//sqJS9m",
+	"815DYJcnzyHFEpIyPLRWS15zRFqhXmeE1z7fGTkoWbBl7XoN/93W3K7bBhK8reHAnrr1Jvq7bWG/2zX4",
+	"vW2h++ZY/j0VuneH/59FoXsxvSGhq3IZ9hC4akhdUx6dhe+9sjdAvr7o/Xve0YjAhB+w3eWjqF+pqRKS",
+	"D5DMgXfmaZQCayRoqtISk4TXAGP14gpQhhOIVOnY/grsbeymswBcTcBUdxdBFRV201qmjPr4VLXsv45f",
+	"0bExPPdOEcpmiBdTiFC+1KF9FYYr0M9aW6OijGuMjitoyKFWg5IvPasCi+XbveiiiLetBiZKWm0GzN/d",
+	"hfHr/GzJRm1GLrXxReeO/lbP/BW27hrgR2oDn5KS+ULqf+mCznTltIAXoMxDqX6KkXpOtm8Y6jfiMBhl",
+	"0xE/RV0nyu1X1FqUTNUmbXeJuItGZQOLrk6kMtZVqHqwF8I0nIiQWKZEaVCbnFpirlUDlihhWmckTFW0",
+	"6tdrH4z8hK/VgcMhhR3xaBEz7kCVv7FrFKdMQLqyLWwUb1ocsWjtWO3MV55nRopTAhsPKpdJuxfC1oyu",
+	"hYUVcrRy40qO6T/4CIRB81/V860tp/4YjRqnDYzQ1cWgYKFrW7U+4S4XqIe6uysH6oM5NKb5GS2IFFFN",
+	"fjtMzcDzUeUu3D6t1jxj1VOv2XFebRWZI0yy6Plb7123Q91mX+33Wuij+Wm0BB4DlXi+Zvn89kPfCS7z",
+	"24Z5+zbYPEzRhbEqvjmD4TUaw2o/d6jurM2kddQIqppPn1LPcvlbcfWek2hdbQAP3MTn4OVBj6cf0E5t",
+	"Cg+zg3qbGu4tcFpa1EqduvOExe/OPOEjrLOSwDPruW/3EgcsOk5pblM5c6C6L3fDyEGFYEOaRw5L+Rnd",
+	"5ZXwM3IYsn0h2+eT7auK8feb66sJ7A6ZvtoozyjPV1oK7yzfA6Ta6qc5/JhUiNIG69iXVPTP77Uk45Fl",
+	"99qSu3tuz2vMx5TZ8yB4/3m9tua5h6xepUb3ntMrDvrdU0bPzGTrfJ6hdowuC4w1LsviigfFeqipeEJt",
+	"w2tI03EtGViL3rRGdAZyDP2d2RnLzS2TgzXg2NnkqoSNtxY03jYg420JGG8dcPG29p8FVLytAd7bdTB+",
+	"2wba22YQDeceNH9YbaNnmD00kxuQO7RIfr8tsgqhdAlaU1AHiI5Rgltd6UTzNMXKUe3uyHPYK556CHDB",
+	"0rWmgPtpPN3LiD1dAeUTDeqlZadu0f1Lvv97pTrW0Kc71dYtnro/u34W2dn0ppdNvp1t1nbnWtJ5aBOa",
+	"FkzsoNOrC0w7WtUxWhW9ajdp6WVUvYFKbxeUrfXBfo5v1E9rrB/l0Hk6RZ9S/jk1w46i3VTHfvTBozqu",
+	"cDAFc9Cj3x1i9bhuL3ow6/GUcguPJCjfrxedQfre13oC8IMVqJo9xDkncnWhTJaR77eAOfCzXC7Ku2rV",
+	"S+bPFUsWUi5Hd2oMQmd64WwNRFmthz5WSd0L4FckVlRa/3l0OjoeH4+PtYgtgeIlGZ2OftF/0ih6oYl5",
+	"WTasVf+ag17VMlykquLK1rFCe1DqXY4zkMCFdlTgZplqjTPDqQBF7eh0VNShGKkf2bZMxm57Hl30G1nf",
+	"OzN8aNfdVSbgcGk9nbE6c3o5Ro1rnJRPZboREanDbcsUxzrofql+u9QOvgfVeowG1S1Z8qKxuFfKRac9",
+	"gLoboWaQYZRqJ1CUbtx0ZT2Ty6PK8YrU+Jpqc9uXrmnSj5cBTMxtENO0WTx/50uzGqZB8aBW1pUb6wjp",
+	"dU+1Fm7RGZHqTKWZu6ItKrInJNF5kvTa1FXqN5Mxeq9zOsZVJqKWZDWdAqnhie4O6MsLPZjYnhsbOOHz",
+	"+eI44Sbh8dov1jJPGE1XrgHLbNFd5Aw7Vm25TYxcADKIRfgKVXEi0sHIjjqybrHpJEliCd4U2fOY+yBI",
+	"RVhFAdTjlIDtD7MkICrV8svxaxWtVYJIyvtxxyNLXnkNmqXvfHb0D0ahzP0M0CC/F7mUOikrcxGfCfEm",
+	"kYqpYop++/r1sz5w3yKTSLTAuuGnfQUJQmMYo/M5ZbzYVg0qy+abmyb1kSVkRiA5ulDDbZzY92hkvR5j",
+	"+U+Oj0e6xROVtvugzpWaAM7LPyxMqcbz2aHaKGuY0H2L3gKLZo7VTEvTpJa+o3QNz8GRy2ssahUqNJBo",
+	"wyKPPmAhS+45L1+rl++trXaRSPRnvvrkL8ev3XNrClYlzcUtz1zNnDI0ZcnKzS//mStCXu9x5W3O1r3k",
+	"AvgVcBTrNCllEuVUUS6LAwaFSCQ5GLOlLwBEYkUlvhmPNKmvDk/qme04J1D9ykLz/ZP/ugdWMd14d1U1",
+	"ttYp5SkA1UcuxuiDApbCVlssgZsuQYqN6h/r7W+XmGOpqp5n+iZTnIgIXXOiK1GmtvxZvQs3ulm0vr6Q",
+	"G3tOGTr7fI5+wEqUTYk1Vi1KX9RD9npFpfTKBn66IZEKlOgcj1jb119UIFe9daT/11U6a2eO01Sf9jAp",
+	"w9zU/XaLt89Z1urjXyDDhNpY3v4GFSBdtcwxo4kSKUlSU3+uQkqadxpwXWGiHTiE55jQ3WcJkq+OzjSW",
+	"9yCm2HoxpubGeMkJJDtSoeh4cx/K5cIoFrC/V56tdgLrPu0/vyvDJ/Fc+Yel9zj6bsNTGxzMX4t8+JqL",
+	"uRZXpOTPHNSGKQ8olbe1arCN0bdv5+9UzZfi8cpklkq/Qr3Ha86S6YFb2GnEsb0CFFNTWqaST43OYFp4",
+	"xujvsDJ7s+w9fvIaLVjORTd6SCBbMqliUEd/hyaQzfDNB6BzxcCTN28iN5bQs3yrbNK+1nv9lr27u3V5",
+	"vGuBmFd7BzGDAIxWpFi3U+cgWM5j0A9o5W1dTI0ObY2MN+DxuPFuCN4pV1sprGWKV71XzhJRj5RrA40p",
+	"AsxTArxkRlOcN8lUyzkKUGQYFDn+r/thVWka7MXMolpkC0yNb1i4aIa1Fi6dHJ7GXzVcLpR/wGgBowWM",
+	"9jwx2l1USwm8nBaVfD3A7W3NPO4boTSvl/YCKMeH+XhRjzss2hJM7jCT+/p+WYWp4tOMNDkESYktnw4S",
+	"eHUPSOAz1zqT1M4EBBgSYEiAIQGGHA6G/EWSO8O2FCRswCHFubtWBEmHY5ZYLqpgDEmG+fPOJJ7JT2xz",
+	"23WVr3r96qTKV60lqzbloXrzaveRfjpM6mmvkZgQ9HgWCOxh0U0AGgFoBKDxHHNSm2se/zfIe0ETzkqy",
+	"Oqx4voVkoRTpmZQiPQwWtDdAXwP+YfhoRLUScXNxq6ocbJcwjQ9Qw9RwBvyqmIaB2VDVFFD1vlB1ALYB",
+	"2AZg+zyLrYrkXQe0/VY0HQmxsoOVV60dqH+g7GUI0gU48TME6UIKMgCoAKACgDpQCvJls09B/2Hpz9Xz",
+	"h0FZ4ex1OHv9DM5et68f2vrE8S4nhUPo+cmFnhuXgz2jU7Blg6RwCjY4eCFeHNyd4O4Ed+fg7k5WtXo8",
+	"KvZep6fT7jC5Q4Mo24oy8mSEsyfl88DCbbZuQsWHxFZtSrZAWcHqh94XwbwG8/pTmteaBkVW5DZY2vKY",
+	"Q0fi1mFwD5fCPWg+tD2T+86MdlEQ7NrP5c2GjGGw8cHGBxt/CBvP1u5CcHrR9QsTLkBKQuf2zMHBbF/9",
+	"k9tYveAiBfUZ1GdQn3tRnw1ltKFq1aUnS+/ncJ5KW1fen4+yq54O3om3d/LLfX5/xviUJAlQ8/HggwQj",
+	"GoxoMKL7M6LK+VBbAKdHubCi6nQ+PuuH1D1dHam7Z1FcVk7yoRJpFQUhgRa8w2DYgmELhs3PsBnNiZTq",
+	"3NA/vmbGyg7yh+uVXn3tvrulr3/5YP3Sg815Ou5jaFEeXNyABAIS+KmQwLqH29shtAYRvsAV+3G4mpmD",
+	"+5DBf3y+tjyc+QiWNFjSYEkfwpK+TCGZA+8JFn8wD/VEipWSqe9Ipe1xLBEpOvtcFh+tP2WOUhvWm3Op",
+	"5dZSRs28gbDVnhRljIO5qI3R7vY/hu6jRoz8oaz4F6DScjBY8Web0A3mMpjLYC6flblsWspB5ySt3SRC",
+	"Og5wBEMaziYGoxqMajCqwaj+jEZ1c0rXJFzbuv7ZW81D5a63PXj56rEcvAy57GDkg5EPRj4Y+SfqOUOP",
+	"n/wRgku8S4b4PAEq1doEVzhYyWAlg5UMVvLpWcmG4eoxmJ8azx7cvtQ/t23INRwRCfoz6M+gPw+mPz3u",
+	"aTCvbbqgIXgd/lZhhzbswfMInkewnMFyBsv5GCynPsKVWVo3GM6L8rlnbDcj12RmhCsLgVflQbeCFZHq",
+	"ZqD+on7UB+KusUAcYlAX0vjes85Z5id4tveRF9H6FhE3zViijAmJMFoB5vaGJUuFD8GS7UTufdxLXglr",
+	"gCYBmgRoEqBJgCZPD5qYqpF+n/5r+Vxw6Xdw6Q0bg0cfzGYwm8FsBrP5JM3mjKWEiZd/KRme2BYJnabT",
+	"PGyaj280nOroKDp/VziT5V2VGaZ4DjxCjKPLDC71Bq03iFFkjCJXpwVL4KNqt6D5cZFnGearkOYMqj2o",
+	"9qDaH1S1a4VUaHePFGf5yPb3MobL48Pl8c/j8viuqRIap3kCitWA40WJZoq5C8kB5ITm2RR4VPwLZxCJ",
+	"fJrz6eUYkUSrxfQar0Qxnr2anQPillM4SQxDLuFmiWlyOUbvr4Cv0EzRgkj1qgkrUEYNL8u72L3yB3pi",
+	"23Nx1+v3k4SDEH1yd+ib/D9RhTZK/YewUAYeErXgckGEsfzn73qhqhehdvCJZA0yM0I/AJ0rXf7KI0+z",
+	"TjO7pkZe5AKESuGYZIbwpKp43qDqgdJgdve511Zap5tQS7HZH770mqfdpK7xbheaJJbgTZI0OauDUrRk",
+	"QsYs8SaqeP5AdClEBwliVO0MYzH1hrHpOx8KjRuYAZUTM9rEphN3SGJuJNMazN3plGy/VOoOqRhVH0Iz",
+	"0LYcS5QCFtLQvAQeA5V4vgXlM4BJRmgH3SyfpjXKjRHblfKM7Y1wfLMr4V8gxaZjncUjpUUvDHihCC89",
+	"aTTWeXsL+t6877H33n/Fc1FEUWLdv/aFQDFbEhAVLvzl+DUiM8QoKIxg7VNnIuF8dvQPRuHoo/KWh8G/",
+	"34tkRp2UlakdiBeYzgtQQ9FvX79+1lutRSbRsRD6onwFCUJjGKPzOWVlyqRBpZpWiXG6JvWRJWRGIDm6",
+	"UMM9XG5k+0LHRlhBLX3b2f1qXYAXAl0BFzooooDJ+qL6x8ii0QcsZMm90alz0XEN9Sq1T5HZBJCUsCNa",
+	"kwNL1oBlUcT8cvy6TcLXlshVcq7DOHIBXPGEMjRVZ+KdnPTnyV1IUIVAYggkhkDizxlItJp+U/OVEgeW",
+	"12lszgdR8mcOaseUbrTlrcVgGH37dv5ujM40k1fVzQE6XqTe47UgmKmwLEw44ljpf1NCoeNQhM6beSct",
+	"PWP0d1iZzfkDllJTcvIaLVjORTewSCBbMgk0Xh39HZpRhgzfFO77yZs30f02ailX6b6vGGl892BNWXyx",
+	"kKXGgYe2gULlaiuNtUzxqgMQWXkj2h4aabayKJkGR5inBHjJjKY4b5KpVuQqYJGBxTLhtpNw20kAaQGk",
+	"/TQgrZnsfTktrsfug25vawZy3xilIM98456vxW58/IsGFCJUqB7U6D7Oa0meAhZ4dQ9Y4DPXWpOof6IZ",
+	"JmkZuAlAJACRAEQCEDkEEOm9ca189MwUchzixjV3HtMkKZoxIp2aeiFU/lJ79lU8QdVlKV2hwhBVOuv1",
+	"q5MqnbWWy9qUpupNu91HdupAmal9RmNC4ONZYLCHxTcBagSoEaDGc0xM9RS0Ow8pHQBOPOXqImeJcx0T",
+	"hQrnUJ71TMuzHgYAj9G5WpZrwD8MH8uNwaGqqFJnQczWLEU/0iZf1bm3y73GB6j3anhGfnVdw5B9qPMK",
+	"Lka4fTqg/IDyA8rfVH5WJDO7cP63ZeKsP/t5I4f7T+YaHg8rOAshy4AnQsgypGQDggoIKiCox5SSNW0S",
+	"pW9XkK/26cMgrNBhJHQYeQYdRswm6ekvcuiWFiEa/eSi0UZunttRYWNgwnHg4NaFMHFwcoKTE5ycwzs5",
+	"AhR06vRpLvTP/8eCrc1N3+FGKs2q9QmhiOJM7TYlb6mIUMamJLW7zvYyU/Dq15QJQFmxJTmgGcup3ljX",
+	"kKbq/+EGxxIxCsIXjP65cRFrx49fHR9H9W5iJ76tiMypattoSr2iAaTNSntSqb80GDKbFTGnc76qETzq",
+	"LZTS172FFkqFSmbJj9DJsUL5CcxwnkpPsj2cvwzfkCzPRqdvDHvNP145tsshQWKdU+EcU2hkEiBCgAgB",
+	"InhABKM4LUDoi3raOGdohBzClD93mNK3DbLZUMW8FV3REhPVIvOZlAMbbu0Y0NX/9xh6HVv9p7bHU2x7",
+	"XCPfdsWtX8ztpZ+tw3g/7Y4Lepd4pbpRadZhaWmfaXtMY3/iizdW25BOJU7/Vg4wfAakEo9En2LnKMXS",
+	"WwysVthXj9+NdNnGS1YrrUBI4OpWUy3F9kUFNjkHzMXACeyl+W8KWIBAQBMlF7t0UQaaTNTj+2Ksi7It",
+	"GyeXpO2FZWt7qdkaOcvjhd5enqRxvR0mOGM5lXtrjNwmMWN7ovA+OiAXunF8X4eVjG0NjZBDbvNJ5jZb",
+	"TZDLWmBtG0ND5BBHDHHEEEcMccRnEUc0lmxDO2TzwOPuhWyLcp5hJ2S7PPfcB7n+1YfugmzWNvRADvAj",
+	"9EAO51wCLgu47OfCZbX8bl/7Y/POQXsfm088SOfj2qdDvVDoexz6HodDtgF8BPARwMc9gY++lsfmpQft",
+	"d2xI/Tm6HW8Ro3mImEsIb4S2IaHTcQAXAVwEcNHKOG0qVn88TY4ffQFRZ6/jUMYcKq2eRaXVQ8DcJ9XP",
+	"eFBbitDNOLgRoU1FQPIByQckv6/aMXcvY/PEQzYyfpQhwUO1MR5SLRYikQFChEhkyK0G0BRAUwBNjyC3",
+	"+nKJVxl4tvH4XDx7AEx10CuqDN1bnJwL1jsEAIItC7Ys2LKnenjM6v6DmaxDncEq6L7nQ1iNzx7sFFYw",
+	"q8/CrAa/NNjyYMuDLd+HX6rfUYO4Tnh/5izJY/UPZL40ikY5T0eno4WUS3H6sriSZ3WUYYrnoGzYeJau",
+	"xglcjdrx+g8sxil6B1eQsqV61jXs6cuXqXpuwYQ8/c/j/zwe1Sj/q8AMH2x5jv6K/Vt1wV71tyJeXf3l",
+	"U01LNN5mXM5YSlj9j7bz5tpjOEXfBPD6nz9itfwU0xiQ3cTtt0Z33+/+/wDTOfJowd8BAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	// the probes don't need a session
+	c := newPortalClient(t)
+	c.userID = ""
+
+	expect[map[string]any](t, c.do(http.MethodGet, "/healthz", nil), http.StatusOK)
+
+	ready := expect[struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}](t, c.do(http.MethodGet, "/readyz", nil), http.StatusOK)
+
+	if ready.Checks["database"] != "ok" || ready.Checks["migrations"] != "ok" {
+		t.Errorf("expected the database and migrations checks to pass, got %v", ready.Checks)
+	}
+
+	version := expect[struct {
+		GitSHA        string `json:"git_sha"`
+		SchemaVersion *int64 `json:"schema_version"`
+	}](t, c.do(http.MethodGet, "/version", nil), http.StatusOK)
+
+	if version.GitSHA != "test" || version.SchemaVersion == nil || *version.SchemaVersion == 0 {
+		t.Errorf("expected the build and schema version, got %+v", version)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/google/uuid"
+)
+
+// backdateIdempotencyKey makes a key look like it was claimed by a request that
+// stopped before it finished
+func backdateIdempotencyKey(t *testing.T, organisationID string, key string) {
+	t.Helper()
+
+	_, err := testPool.Exec(context.Background(), `
+		UPDATE idempotency_keys
+		SET created_at = created_at - INTERVAL '1 hour'
+		WHERE
+			organisation_id = $1
+			AND key = $2
+	`, organisationID, key)
+
+	if err != nil {
+		t.Fatalf("backdating idempotency key: %v", err)
+	}
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	c := newStaffClient(t)
+	ctx := context.Background()
+
+	idempotency := storage.NewPostgresIdempotencyRepository(testPool)
+	transactor := storage.NewPostgresTransactor(testPool)
+
+	key := uuid.NewString()
+	response := storage.StoredResponse{Status: http.StatusCreated, Body: []byte(`{"id":"1"}`), ETag: `"1"`}
+
+	if stored, err := idempotency.Claim(ctx, c.orgID, key, "first"); err != nil || stored != nil {
+		t.Fatalf("expected the key to be claimed, got %v, %v", stored, err)
+	}
+
+	if _, err := idempotency.Claim(ctx, c.orgID, key, "first"); !errors.Is(err, storage.ErrIdempotencyKeyInUse) {
+		t.Errorf("expected a retry to find the key in use, got %v", err)
+	}
+
+	if _, err := idempotency.Claim(ctx, c.orgID, key, "second"); !errors.Is(err, storage.ErrIdempotencyKeyReused) {
+		t.Errorf("expected another request to find the key reused, got %v", err)
+	}
+
+	if err := idempotency.Release(ctx, c.orgID, key); err != nil {
+		t.Fatalf("releasing key: %v", err)
+	}
+
+	// a released key can be used by any request
+	if stored, err := idempotency.Claim(ctx, c.orgID, key, "second"); err != nil || stored != nil {
+		t.Fatalf("expected the released key to be claimed, got %v, %v", stored, err)
+	}
+
+	// the first request stopped, and its key is taken over by a retry
+	backdateIdempotencyKey(t, c.orgID, key)
+
+	if stored, err := idempotency.Claim(ctx, c.orgID, key, "second"); err != nil || stored != nil {
+		t.Fatalf("expected the key to be taken over, got %v, %v", stored, err)
+	}
+
+	err := transactor.InTx(ctx, func(repositories storage.RecordRepositories) error {
+		return repositories.Idempotency.Complete(ctx, c.orgID, key, response)
+	})
+
+	if err != nil {
+		t.Fatalf("completing key: %v", err)
+	}
+
+	// the first request can't store its response once the retry has
+	err = transactor.InTx(ctx, func(repositories storage.RecordRepositories) error {
+		return repositories.Idempotency.Complete(ctx, c.orgID, key, response)
+	})
+
+	if !errors.Is(err, storage.ErrIdempotencyKeyInUse) {
+		t.Errorf("expected a second response to be rejected, got %v", err)
+	}
+
+	// a completed key is kept, and replayed
+	if err := idempotency.Release(ctx, c.orgID, key); err != nil {
+		t.Fatalf("releasing key: %v", err)
+	}
+
+	stored, err := idempotency.Claim(ctx, c.orgID, key, "second")
+
+	if err != nil || stored == nil {
+		t.Fatalf("expected the response to be replayed, got %v, %v", stored, err)
+	}
+
+	if stored.Status != response.Status || string(stored.Body) != string(response.Body) || stored.ETag != response.ETag {
+		t.Errorf("expected %+v to be replayed, got %+v", response, *stored)
+	}
+}
+
+func TestIdempotencyKeyRetries(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+
+	payload := models.CreateTenant{
+		PropertyId:        uuid.New(),
+		Name:              "Alex Renter",
+		Email:             "tenant@example.com",
+		Mobile:            "0411111111",
+		RentalAmount:      650,
+		Frequency:         "weekly",
+		OriginalStartDate: testDate(-180),
+		StartDate:         testDate(-180),
+		EndDate:           testDate(185),
+		PaidTo:            testDate(7),
+	}
+
+	firstKey := uuid.NewString()
+	headers := map[string]string{"Idempotency-Key": firstKey}
+
+	// a failed create releases its key, so the request can be corrected and retried
+	expectError(t, c.doWithHeaders(http.MethodPost, "/tenants", payload, headers), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	payload.PropertyId = *property.Id
+	first := expect[models.Tenant](t, c.doWithHeaders(http.MethodPost, "/tenants", payload, headers), http.StatusCreated)
+
+	rec := c.doWithHeaders(http.MethodPost, "/tenants", payload, headers)
+	retried := expect[models.Tenant](t, rec, http.StatusCreated)
+
+	if *retried.Id != *first.Id || rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the retry to replay tenant %s, got %s", first.Id, retried.Id)
+	}
+
+	// a key claimed by the same request, which is still running
+	key := uuid.NewString()
+	headers = map[string]string{"Idempotency-Key": key}
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO idempotency_keys (organisation_id, key, request_hash)
+		SELECT organisation_id, $3, request_hash
+		FROM idempotency_keys
+		WHERE
+			organisation_id = $1
+			AND key = $2
+	`, c.orgID, firstKey, key)
+
+	if err != nil {
+		t.Fatalf("claiming idempotency key: %v", err)
+	}
+
+	expectError(t, c.doWithHeaders(http.MethodPost, "/tenants", payload, headers), http.StatusConflict, models.Conflict)
+
+	// and one claimed by a request that stopped before it finished
+	backdateIdempotencyKey(t, c.orgID, key)
+
+	taken := expect[models.Tenant](t, c.doWithHeaders(http.MethodPost, "/tenants", payload, headers), http.StatusCreated)
+
+	rec = c.doWithHeaders(http.MethodPost, "/tenants", payload, headers)
+	replayed := expect[models.Tenant](t, rec, http.StatusCreated)
+
+	if *replayed.Id != *taken.Id || rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the retry to replay tenant %s, got %s", taken.Id, replayed.Id)
+	}
+
+	list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants", nil), http.StatusOK)
+
+	if list.Pagination.Total != 2 {
+		t.Errorf("expected one tenant for each key, got %d", list.Pagination.Total)
+	}
+}
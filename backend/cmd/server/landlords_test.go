@@ -0,0 +1,247 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func createLandlord(t *testing.T, c testClient, name string) models.Landlord {
+	t.Helper()
+
+	rec := c.do(http.MethodPost, "/landlords", models.CreateLandlord{
+		Name:         name,
+		Email:        "landlord@example.com",
+		Mobile:       "0400000000",
+		AddressLine1: "1 George Street",
+		Suburb:       "Sydney",
+		State:        "NSW",
+		Postcode:     "2000",
+		Country:      "Australia",
+	})
+
+	return expect[models.Landlord](t, rec, http.StatusCreated)
+}
+
+func TestLandlords(t *testing.T) {
+	c := newStaffClient(t)
+
+	created := createLandlord(t, c, "Jane Citizen")
+	createLandlord(t, c, "John Smith")
+
+	if created.Id == nil {
+		t.Fatal("expected the created landlord to have an ID")
+	}
+
+	path := "/landlords/" + created.Id.String()
+
+	got := expect[models.Landlord](t, c.do(http.MethodGet, path, nil), http.StatusOK)
+
+	if got.Name != "Jane Citizen" {
+		t.Errorf("expected name %q, got %q", "Jane Citizen", got.Name)
+	}
+
+	list := expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?name=jane", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 || len(list.Items) != 1 || *list.Items[0].Id != *created.Id {
+		t.Errorf("expected the name filter to match only the created landlord, got %+v", list)
+	}
+
+	updated := expect[models.Landlord](t, c.do(http.MethodPatch, path, map[string]any{"name": "Jane Doe"}), http.StatusOK)
+
+	if updated.Name != "Jane Doe" {
+		t.Errorf("expected name %q, got %q", "Jane Doe", updated.Name)
+	}
+
+	archived := expect[models.Landlord](t, c.do(http.MethodDelete, path, nil), http.StatusOK)
+
+	if archived.IsArchived == nil {
+		t.Error("expected the landlord to be archived")
+	}
+
+	list = expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?archived_only=true", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 {
+		t.Errorf("expected 1 archived landlord, got %d", list.Pagination.Total)
+	}
+
+	// an update leaves out is_archived, so it stays archived
+	updated = expect[models.Landlord](t, c.do(http.MethodPatch, path, map[string]any{"phone": "0299999999", "address_line_2": "Level 2"}), http.StatusOK)
+
+	if updated.IsArchived == nil || updated.Phone == nil || *updated.Phone != "0299999999" {
+		t.Errorf("expected the archived landlord's phone to be set, got %+v", updated)
+	}
+
+	updated = expect[models.Landlord](t, c.do(http.MethodPatch, path, map[string]any{"phone": nil, "address_line_2": nil}), http.StatusOK)
+
+	if updated.Phone != nil || updated.AddressLine2 != nil {
+		t.Errorf("expected the phone and address line 2 to be cleared, got %+v", updated)
+	}
+
+	updated = expect[models.Landlord](t, c.do(http.MethodPatch, path, map[string]any{"is_archived": nil}), http.StatusOK)
+
+	if updated.IsArchived != nil {
+		t.Errorf("expected the landlord to be unarchived, got %v", updated.IsArchived)
+	}
+}
+
+func TestLandlordsPagination(t *testing.T) {
+	c := newStaffClient(t)
+
+	for _, name := range []string{"A", "B", "C"} {
+		createLandlord(t, c, name)
+	}
+
+	list := expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?limit=2&page=2", nil), http.StatusOK)
+
+	if list.Pagination.Total != 3 || list.Pagination.TotalPages != 2 || len(list.Items) != 1 {
+		t.Errorf("expected the last page of 3 landlords, got %+v", list.Pagination)
+	}
+
+	list = expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?limit=2", nil), http.StatusOK)
+
+	if list.Cursors.Next == nil || list.Cursors.Previous != nil {
+		t.Fatalf("expected only a next cursor on the first page, got %+v", list.Cursors)
+	}
+
+	// a landlord added before the cursor doesn't shift the next page
+	createLandlord(t, c, "0")
+
+	list = expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?limit=2&after="+*list.Cursors.Next, nil), http.StatusOK)
+
+	if list.Pagination != nil || len(list.Items) != 1 || list.Items[0].Name != "C" {
+		t.Fatalf("expected the page after the cursor to hold only C, got %+v", list)
+	}
+
+	if list.Cursors.Next != nil || list.Cursors.Previous == nil {
+		t.Fatalf("expected only a previous cursor on the last page, got %+v", list.Cursors)
+	}
+
+	list = expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords?limit=2&before="+*list.Cursors.Previous, nil), http.StatusOK)
+
+	if len(list.Items) != 2 || list.Items[0].Name != "A" || list.Items[1].Name != "B" {
+		t.Errorf("expected the page before the cursor to hold A and B, got %+v", list.Items)
+	}
+
+	if list.Cursors.Previous == nil {
+		t.Errorf("expected a previous cursor for the landlord added before A, got %+v", list.Cursors)
+	}
+
+	expectError(t, c.do(http.MethodGet, "/landlords?after=not-a-cursor", nil), http.StatusBadRequest, models.InvalidRequest)
+	expectError(t, c.do(http.MethodGet, "/landlords?page=2&after="+*list.Cursors.Next, nil), http.StatusBadRequest, models.InvalidRequest)
+}
+
+func TestLandlordsErrors(t *testing.T) {
+	c := newStaffClient(t)
+
+	expectError(t, c.do(http.MethodGet, "/landlords/not-a-uuid", nil), http.StatusBadRequest, models.InvalidId)
+	expectError(t, c.do(http.MethodGet, "/landlords/00000000-0000-0000-0000-000000000000", nil), http.StatusNotFound, models.NotFound)
+	expect[models.Error](t, c.do(http.MethodPost, "/landlords", map[string]any{"name": "Missing Fields"}), http.StatusBadRequest)
+}
+
+func TestLandlordsAreScopedToOrganisation(t *testing.T) {
+	c := newStaffClient(t)
+	other := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+
+	expectError(t, other.do(http.MethodGet, "/landlords/"+landlord.Id.String(), nil), http.StatusNotFound, models.NotFound)
+
+	list := expect[models.LandlordList](t, other.do(http.MethodGet, "/landlords", nil), http.StatusOK)
+
+	if list.Pagination.Total != 0 {
+		t.Errorf("expected no landlords in another organisation, got %d", list.Pagination.Total)
+	}
+}
+
+func TestLandlordsFields(t *testing.T) {
+	c := newStaffClient(t)
+
+	createLandlord(t, c, "Jane Citizen")
+
+	list := expect[map[string]any](t, c.do(http.MethodGet, "/landlords?fields=name,mobile", nil), http.StatusOK)
+
+	items, _ := list["items"].([]any)
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 landlord, got %v", list["items"])
+	}
+
+	item, _ := items[0].(map[string]any)
+
+	if len(item) != 3 || item["id"] == nil || item["name"] != "Jane Citizen" || item["mobile"] != "0400000000" {
+		t.Errorf("expected only the id, name and mobile, got %v", item)
+	}
+
+	expectError(t, c.do(http.MethodGet, "/landlords?fields=password", nil), http.StatusBadRequest, models.InvalidRequest)
+}
+
+func TestLandlordsIfMatch(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	path := "/landlords/" + landlord.Id.String()
+
+	rec := c.do(http.MethodGet, path, nil)
+	tag := rec.Header().Get("ETag")
+	expect[models.Landlord](t, rec, http.StatusOK)
+
+	if tag == "" {
+		t.Fatal("expected an ETag header")
+	}
+
+	rec = c.doWithHeaders(http.MethodPatch, path, map[string]any{"name": "Jane Doe"}, map[string]string{"If-Match": tag})
+	updatedTag := rec.Header().Get("ETag")
+	expect[models.Landlord](t, rec, http.StatusOK)
+
+	if updatedTag == "" || updatedTag == tag {
+		t.Errorf("expected a new ETag after the update, got %q", updatedTag)
+	}
+
+	expectError(t, c.doWithHeaders(http.MethodPatch, path, map[string]any{"name": "John Smith"}, map[string]string{"If-Match": tag}), http.StatusPreconditionFailed, models.PreconditionFailed)
+	expectError(t, c.doWithHeaders(http.MethodDelete, path, nil, map[string]string{"If-Match": tag}), http.StatusPreconditionFailed, models.PreconditionFailed)
+	expectError(t, c.doWithHeaders(http.MethodDelete, path, nil, map[string]string{"If-Match": `W/` + updatedTag}), http.StatusPreconditionFailed, models.PreconditionFailed)
+	expectError(t, c.doWithHeaders(http.MethodDelete, "/landlords/00000000-0000-0000-0000-000000000000", nil, map[string]string{"If-Match": updatedTag}), http.StatusNotFound, models.NotFound)
+
+	expect[models.Landlord](t, c.doWithHeaders(http.MethodDelete, path, nil, map[string]string{"If-Match": tag + ", " + updatedTag}), http.StatusOK)
+}
+
+func TestLandlordsIdempotencyKey(t *testing.T) {
+	c := newStaffClient(t)
+	other := newStaffClient(t)
+
+	payload := models.CreateLandlord{
+		Name:         "Jane Citizen",
+		Email:        "landlord@example.com",
+		Mobile:       "0400000000",
+		AddressLine1: "1 George Street",
+		Suburb:       "Sydney",
+		State:        "NSW",
+		Postcode:     "2000",
+		Country:      "Australia",
+	}
+
+	headers := map[string]string{"Idempotency-Key": "8f0f7a4e-create-landlord"}
+
+	first := expect[models.Landlord](t, c.doWithHeaders(http.MethodPost, "/landlords", payload, headers), http.StatusCreated)
+
+	rec := c.doWithHeaders(http.MethodPost, "/landlords", payload, headers)
+	retried := expect[models.Landlord](t, rec, http.StatusCreated)
+
+	if *retried.Id != *first.Id || rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the retry to replay landlord %s, got %s", first.Id, retried.Id)
+	}
+
+	list := expect[models.LandlordList](t, c.do(http.MethodGet, "/landlords", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 {
+		t.Errorf("expected the retry not to create another landlord, got %d", list.Pagination.Total)
+	}
+
+	payload.Name = "John Smith"
+	expectError(t, c.doWithHeaders(http.MethodPost, "/landlords", payload, headers), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	// keys are per organisation
+	expect[models.Landlord](t, other.doWithHeaders(http.MethodPost, "/landlords", payload, headers), http.StatusCreated)
+}
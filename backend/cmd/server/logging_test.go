@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/config"
+	"github.com/davidtaing/property-management/internal/logging"
+	"github.com/davidtaing/property-management/internal/middleware"
+)
+
+func TestRequestLogRedaction(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("CLERK_KEY", "sk_test")
+
+	cfg, err := config.NewConfig()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	logger := slog.New(logging.NewRedactingHandler(slog.NewJSONHandler(&buf, nil), cfg.LogRedactFields))
+	handler := middleware.LoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search?q=jane%40example.com&limit=5", nil))
+
+	var line struct {
+		Query map[string]any `json:"query"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("decoding log line: %v: %s", err, buf.String())
+	}
+
+	if line.Query["q"] != logging.Redacted {
+		t.Errorf("expected the search query to be redacted, got %v", line.Query["q"])
+	}
+
+	if bytes.Contains(buf.Bytes(), []byte("jane")) {
+		t.Errorf("expected the search query to be left out of the log: %s", buf.String())
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/davidtaing/property-management/api"
+	"github.com/davidtaing/property-management/internal/config"
+	"github.com/davidtaing/property-management/internal/health"
+	"github.com/davidtaing/property-management/internal/metrics"
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/ratelimit"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/testdb"
+	"github.com/davidtaing/property-management/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Headers read by fakeAuthenticate in place of a Clerk session token
+const (
+	testUserHeader = "X-Test-User"
+	testOrgHeader  = "X-Test-Org"
+	testRoleHeader = "X-Test-Role"
+)
+
+// orgAdminRole is the Clerk role given to staff test users
+const orgAdminRole = "org:admin"
+
+var (
+	testHandler http.Handler
+	testPool    *pgxpool.Pool
+	// testDeps are what testHandler is built from, for tests that need a handler
+	// with a different config
+	testDeps handlerDeps
+
+	// testMetrics are the request metrics recorded by testHandler
+	testMetrics *metrics.HTTP
+	// testSpans are the spans recorded by testHandler and testPool
+	testSpans *tracetest.SpanRecorder
+
+	// testMembers are the staff clients' organisations, looked up in place of Clerk
+	testMembers = &testDirectory{members: map[string]bool{}}
+
+	// skipReason is set when there's no database to run the tests against
+	skipReason string
+)
+
+// testDirectory knows the memberships it's been told about
+type testDirectory struct {
+	mu      sync.Mutex
+	members map[string]bool
+}
+
+func (d *testDirectory) add(organisationID string, userID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.members[organisationID+"/"+userID] = true
+}
+
+func (d *testDirectory) IsMember(ctx context.Context, organisationID string, userID string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.members[organisationID+"/"+userID], nil
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	ctx := context.Background()
+
+	db, err := testdb.Start(ctx)
+
+	if errors.Is(err, testdb.ErrUnavailable) {
+		skipReason = err.Error()
+		return m.Run()
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to start the test database:", err)
+		return 1
+	}
+
+	defer db.Close(ctx)
+
+	if err := db.Migrate(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to migrate the test database:", err)
+		return 1
+	}
+
+	// spans are recorded in memory in place of an exporter
+	if _, err := tracing.Setup(ctx, &config.Config{TracesExporter: config.TracesExporterNone}, "test"); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to set up tracing:", err)
+		return 1
+	}
+
+	testSpans = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpans)))
+
+	poolConfig, err := pgxpool.ParseConfig(db.URL)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to parse the test database URL:", err)
+		return 1
+	}
+
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	testPool, err = pgxpool.NewWithConfig(ctx, poolConfig)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to connect to the test database:", err)
+		return 1
+	}
+
+	defer testPool.Close()
+
+	swagger, err := api.GetSwagger()
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error loading swagger spec:", err)
+		return 1
+	}
+
+	swagger.Servers = nil
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repositories := storage.NewPostgresRepositories(testPool)
+	server := api.NewServer(repositories, testMembers, logger)
+
+	healthHandler, err := health.NewHandler(testPool, health.NewBuildInfo("test", ""), logger)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load migrations:", err)
+		return 1
+	}
+
+	testMetrics = metrics.NewHTTP(prometheus.NewRegistry())
+
+	deps := handlerDeps{
+		server:  server,
+		health:  healthHandler,
+		metrics: testMetrics,
+		tiers:   metrics.NewTierCache(repositories.Organisations, logger),
+		swagger: swagger,
+		limiter: ratelimit.NewLimiter(),
+	}
+
+	testDeps = deps
+	testHandler = newHandler(deps, &config.Config{
+		Env:            "TEST",
+		RequestTimeout: 30 * time.Second,
+	}, logger, fakeAuthenticate)
+
+	return m.Run()
+}
+
+// fakeAuthenticate trusts the test headers instead of verifying a Clerk session token
+func fakeAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Header.Get(testUserHeader)
+
+		if userID != "" {
+			claims := &clerk.SessionClaims{
+				RegisteredClaims: clerk.RegisteredClaims{Subject: userID},
+				Claims: clerk.Claims{
+					ActiveOrganizationID:   r.Header.Get(testOrgHeader),
+					ActiveOrganizationRole: r.Header.Get(testRoleHeader),
+				},
+			}
+
+			r = r.WithContext(clerk.ContextWithSessionClaims(r.Context(), claims))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// testClient sends requests to the API as a single user
+type testClient struct {
+	t      *testing.T
+	userID string
+	orgID  string
+	role   string
+}
+
+// newStaffClient seeds a new organisation and returns a client for one of its admins.
+// Each test gets its own organisation so tests can't see each other's records.
+func newStaffClient(t *testing.T) testClient {
+	t.Helper()
+
+	if testHandler == nil {
+		t.Skip(skipReason)
+	}
+
+	c := testClient{
+		t:      t,
+		userID: "user_" + uuid.NewString(),
+		orgID:  seedOrganisation(t),
+		role:   orgAdminRole,
+	}
+
+	testMembers.add(c.orgID, c.userID)
+
+	return c
+}
+
+// newPortalClient returns a client for a user who isn't a member of any organisation
+func newPortalClient(t *testing.T) testClient {
+	t.Helper()
+
+	if testHandler == nil {
+		t.Skip(skipReason)
+	}
+
+	return testClient{t: t, userID: "user_" + uuid.NewString()}
+}
+
+func seedOrganisation(t *testing.T) string {
+	t.Helper()
+
+	id := "org_" + uuid.NewString()
+
+	_, err := testPool.Exec(context.Background(), `INSERT INTO organisations (id) VALUES ($1)`, id)
+
+	if err != nil {
+		t.Fatalf("seeding organisation: %v", err)
+	}
+
+	return id
+}
+
+func (c testClient) do(method string, path string, body any) *httptest.ResponseRecorder {
+	c.t.Helper()
+
+	rec := httptest.NewRecorder()
+	testHandler.ServeHTTP(rec, c.newRequest(method, path, body))
+
+	return rec
+}
+
+// doWithHeaders sends a request with extra headers, e.g. conditional request headers
+func (c testClient) doWithHeaders(method string, path string, body any, headers map[string]string) *httptest.ResponseRecorder {
+	c.t.Helper()
+
+	req := c.newRequest(method, path, body)
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	rec := httptest.NewRecorder()
+	testHandler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (c testClient) newRequest(method string, path string, body any) *http.Request {
+	c.t.Helper()
+
+	var reader io.Reader
+
+	if body != nil {
+		b, err := json.Marshal(body)
+
+		if err != nil {
+			c.t.Fatalf("encoding request body: %v", err)
+		}
+
+		reader = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.userID != "" {
+		req.Header.Set(testUserHeader, c.userID)
+		req.Header.Set(testOrgHeader, c.orgID)
+		req.Header.Set(testRoleHeader, c.role)
+	}
+
+	return req
+}
+
+// expect fails the test unless the response has the given status, then decodes the body
+func expect[T any](t *testing.T, rec *httptest.ResponseRecorder, status int) T {
+	t.Helper()
+
+	var body T
+
+	if rec.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding response body: %v: %s", err, rec.Body.String())
+	}
+
+	return body
+}
+
+// expectError fails the test unless the response is an error with the given status and code
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, errorCode models.ErrorCode) models.Error {
+	t.Helper()
+
+	body := expect[models.Error](t, rec, status)
+
+	if body.ErrorCode != errorCode {
+		t.Fatalf("expected error code %q, got %q: %s", errorCode, body.ErrorCode, body.Message)
+	}
+
+	return body
+}
+
+func TestUnauthenticated(t *testing.T) {
+	c := newPortalClient(t)
+	c.userID = ""
+
+	expectError(t, c.do(http.MethodGet, "/landlords", nil), http.StatusUnauthorized, models.Unauthorized)
+}
+
+func TestNoActiveOrganisation(t *testing.T) {
+	c := newPortalClient(t)
+
+	expectError(t, c.do(http.MethodGet, "/landlords", nil), http.StatusForbidden, models.Forbidden)
+}
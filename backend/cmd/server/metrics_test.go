@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/metrics"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+
+	counter := testMetrics.Requests.WithLabelValues("/landlords/{id}", http.MethodGet, "200", "standard")
+	before := testutil.ToFloat64(counter)
+
+	expect[map[string]any](t, c.do(http.MethodGet, "/landlords/"+landlord.Id.String(), nil), http.StatusOK)
+
+	if after := testutil.ToFloat64(counter); after-before != 1 {
+		t.Errorf("expected the request to be counted against the route template, got %v", after-before)
+	}
+}
+
+func TestStatsMetrics(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	createTenant(t, c, *property.Id, "Alex Renter")
+
+	collector := metrics.NewStatsCollector(storage.NewPostgresStatsRepository(testPool), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	problems, err := testutil.CollectAndLint(collector)
+
+	if err != nil || len(problems) > 0 {
+		t.Errorf("expected the stats to be collected, got %v %v", err, problems)
+	}
+
+	// active tenancies, tenancies in arrears and arrears amount for the standard tier in AUD
+	if n := testutil.CollectAndCount(collector); n < 3 {
+		t.Errorf("expected the stats to be collected for at least one tier, got %d metrics", n)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestOrganisationSettings(t *testing.T) {
+	c := newStaffClient(t)
+
+	got := expect[models.Organisation](t, c.do(http.MethodGet, "/organisation", nil), http.StatusOK)
+
+	if got.Timezone != "Australia/Sydney" || got.Currency != "AUD" {
+		t.Errorf("expected the default timezone and currency, got %q and %q", got.Timezone, got.Currency)
+	}
+
+	updated := expect[models.Organisation](t, c.do(http.MethodPatch, "/organisation", map[string]any{
+		"trading_name":           "Harbour Property Co",
+		"abn":                    "51824753556",
+		"default_management_fee": 7.5,
+		"branding":               map[string]any{"primary_colour": "#1A2B3C"},
+	}), http.StatusOK)
+
+	if updated.TradingName == nil || *updated.TradingName != "Harbour Property Co" {
+		t.Errorf("expected the trading name to be updated, got %v", updated.TradingName)
+	}
+
+	if updated.Branding.PrimaryColour == nil || *updated.Branding.PrimaryColour != "#1A2B3C" {
+		t.Errorf("expected the primary colour to be updated, got %v", updated.Branding.PrimaryColour)
+	}
+
+	cleared := expect[models.Organisation](t, c.do(http.MethodPatch, "/organisation", map[string]any{
+		"trading_name": nil,
+		"branding":     map[string]any{"primary_colour": nil},
+	}), http.StatusOK)
+
+	if cleared.TradingName != nil || cleared.Branding.PrimaryColour != nil {
+		t.Errorf("expected the trading name and primary colour to be cleared, got %v and %v", cleared.TradingName, cleared.Branding.PrimaryColour)
+	}
+
+	if cleared.Abn == nil || *cleared.Abn != "51824753556" {
+		t.Errorf("expected the ABN to be kept, got %v", cleared.Abn)
+	}
+}
+
+// countOrganisations returns the number of settings rows the organisation has
+func countOrganisations(t *testing.T, organisationID string) int {
+	t.Helper()
+
+	var rows int
+
+	err := testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM organisations WHERE id = $1`, organisationID).Scan(&rows)
+
+	if err != nil {
+		t.Fatalf("counting organisations: %v", err)
+	}
+
+	return rows
+}
+
+func TestOrganisationSettingsDefaults(t *testing.T) {
+	c := newStaffClient(t)
+	c.orgID = "org_" + uuid.NewString()
+
+	got := expect[models.Organisation](t, c.do(http.MethodGet, "/organisation", nil), http.StatusOK)
+
+	if got.Timezone != "Australia/Sydney" || got.Currency != "AUD" || got.Tier == nil || *got.Tier != "standard" {
+		t.Errorf("expected the default settings, got %+v", got)
+	}
+
+	// the settings row is only created when the settings are first changed
+	if rows := countOrganisations(t, c.orgID); rows != 0 {
+		t.Errorf("expected reading the settings not to create them, got %d rows", rows)
+	}
+
+	expect[models.Organisation](t, c.do(http.MethodPatch, "/organisation", map[string]any{"currency": "NZD"}), http.StatusOK)
+
+	if rows := countOrganisations(t, c.orgID); rows != 1 {
+		t.Errorf("expected changing the settings to create them, got %d rows", rows)
+	}
+}
+
+func TestOrganisationSettingsValidation(t *testing.T) {
+	c := newStaffClient(t)
+
+	body := expectError(t, c.do(http.MethodPatch, "/organisation", map[string]any{"abn": "123"}), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if body.Field == nil || *body.Field != "abn" {
+		t.Errorf("expected the error to be reported against abn, got %v", body.Field)
+	}
+
+	c.role = "org:member"
+
+	expectError(t, c.do(http.MethodPatch, "/organisation", map[string]any{"trading_name": "Harbour Property Co"}), http.StatusForbidden, models.Forbidden)
+}
+
+func TestDefaultManagementFee(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+
+	payload := models.CreateProperty{
+		LandlordId:       *landlord.Id,
+		StreetNumber:     "10",
+		StreetName:       "Pitt Street",
+		Suburb:           "Sydney",
+		State:            "NSW",
+		Postcode:         "2000",
+		Country:          "Australia",
+		ManagementGained: testDate(0),
+	}
+
+	expectError(t, c.do(http.MethodPost, "/properties", payload), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	expect[models.Organisation](t, c.do(http.MethodPatch, "/organisation", map[string]any{"default_management_fee": 7.5}), http.StatusOK)
+
+	property := expect[models.Property](t, c.do(http.MethodPost, "/properties", payload), http.StatusCreated)
+
+	if property.ManagementFee != 7.5 {
+		t.Errorf("expected the default management fee of 7.5, got %v", property.ManagementFee)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPayments(t *testing.T) {
+	f := newPortalFixture(t)
+
+	path := "/tenants/" + f.tenancy.Id.String() + "/payments"
+	payload := models.CreatePayment{Amount: 650, ReceivedOn: testDate(0), PaidTo: testDate(14)}
+
+	created := expect[models.Payment](t, f.staff.do(http.MethodPost, path, payload), http.StatusCreated)
+
+	// the tenant was paid to a week from today, so the payment covers the next week
+	if created.PaidFrom.String() != testDate(8).String() || created.PaidTo.String() != testDate(14).String() {
+		t.Errorf("expected the payment to cover the week after the tenant's paid_to, got %s to %s", created.PaidFrom, created.PaidTo)
+	}
+
+	tenant := expect[models.Tenant](t, f.staff.do(http.MethodGet, "/tenants/"+f.tenancy.Id.String(), nil), http.StatusOK)
+
+	if tenant.PaidTo.String() != testDate(14).String() {
+		t.Errorf("expected the tenant to be paid to %s, got %s", testDate(14), tenant.PaidTo)
+	}
+
+	// recording the same payment again doesn't pay the tenant any further
+	body := expectError(t, f.staff.do(http.MethodPost, path, payload), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if body.Field == nil || *body.Field != "paid_to" {
+		t.Errorf("expected the error to be reported against paid_to, got %v", body.Field)
+	}
+
+	list := expect[models.PaymentList](t, f.staff.do(http.MethodGet, path, nil), http.StatusOK)
+
+	if len(list.Items) != 1 || list.Items[0].Id != created.Id {
+		t.Errorf("expected only the recorded payment, got %+v", list.Items)
+	}
+
+	missing := "/tenants/" + uuid.NewString() + "/payments"
+
+	expectError(t, f.staff.do(http.MethodGet, missing, nil), http.StatusNotFound, models.NotFound)
+	expectError(t, f.staff.do(http.MethodPost, missing, payload), http.StatusNotFound, models.NotFound)
+
+	payload.PaidTo = testDate(21)
+	payload.Amount = 0
+
+	body = expectError(t, f.staff.do(http.MethodPost, path, payload), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if body.Field == nil || *body.Field != "amount" {
+		t.Errorf("expected the error to be reported against amount, got %v", body.Field)
+	}
+}
+
+func TestPortalLedger(t *testing.T) {
+	f := newPortalFixture(t)
+
+	// arrears are counted to today in the organisation's timezone, and test dates are UTC
+	expect[models.Organisation](t, f.staff.do(http.MethodPatch, "/organisation", map[string]any{
+		"timezone":       "UTC",
+		"trading_name":   "Harbour Property Co",
+		"licence_number": "10012345",
+	}), http.StatusOK)
+
+	// the tenant is a week behind
+	expect[models.Tenant](t, f.staff.do(http.MethodPatch, "/tenants/"+f.tenancy.Id.String(), map[string]any{"paid_to": testDate(-7)}), http.StatusOK)
+
+	ledger := expect[models.RentLedger](t, f.tenant.do(http.MethodGet, "/portal/ledger", nil), http.StatusOK)
+
+	if ledger.Tenancy.Id != *f.tenancy.Id || ledger.Currency != "AUD" {
+		t.Errorf("expected the tenant's tenancy in AUD, got %+v", ledger)
+	}
+
+	if ledger.Agency.TradingName == nil || *ledger.Agency.TradingName != "Harbour Property Co" || ledger.Agency.LicenceNumber == nil {
+		t.Errorf("expected the agency's details, got %+v", ledger.Agency)
+	}
+
+	if ledger.DaysInArrears != 7 || ledger.ArrearsAmount != 650 {
+		t.Errorf("expected a week of rent in arrears, got %d days and %v", ledger.DaysInArrears, ledger.ArrearsAmount)
+	}
+
+	payload := models.CreatePayment{Amount: 1300, ReceivedOn: testDate(0), PaidTo: testDate(7)}
+	expect[models.Payment](t, f.staff.do(http.MethodPost, "/tenants/"+f.tenancy.Id.String()+"/payments", payload), http.StatusCreated)
+
+	ledger = expect[models.RentLedger](t, f.tenant.do(http.MethodGet, "/portal/ledger", nil), http.StatusOK)
+
+	if ledger.DaysInArrears != 0 || ledger.ArrearsAmount != 0 || len(ledger.Payments) != 1 {
+		t.Errorf("expected the rent to be paid up with 1 payment, got %+v", ledger)
+	}
+
+	expectError(t, f.landlord.do(http.MethodGet, "/portal/ledger", nil), http.StatusForbidden, models.Forbidden)
+}
+
+func TestPortalStatement(t *testing.T) {
+	f := newPortalFixture(t)
+
+	payload := models.CreatePayment{Amount: 650, ReceivedOn: testDate(0), PaidTo: testDate(14)}
+	payment := expect[models.Payment](t, f.staff.do(http.MethodPost, "/tenants/"+f.tenancy.Id.String()+"/payments", payload), http.StatusCreated)
+
+	path := "/portal/statement?from=" + testDate(-30).String() + "&to=" + testDate(0).String()
+
+	statement := expect[models.LandlordStatement](t, f.landlord.do(http.MethodGet, path, nil), http.StatusOK)
+
+	if len(statement.Items) != 1 || statement.Items[0].PaymentId != payment.Id {
+		t.Fatalf("expected only the payment on the landlord's property, got %+v", statement.Items)
+	}
+
+	// the property's management fee is 5.5%
+	if statement.Items[0].ManagementFee != 35.75 || statement.TotalNetAmount != 614.25 || statement.TotalReceived != 650 {
+		t.Errorf("expected 650 less a 35.75 fee, got %+v", statement)
+	}
+
+	before := "/portal/statement?from=" + testDate(-30).String() + "&to=" + testDate(-1).String()
+	statement = expect[models.LandlordStatement](t, f.landlord.do(http.MethodGet, before, nil), http.StatusOK)
+
+	if len(statement.Items) != 0 || statement.TotalReceived != 0 {
+		t.Errorf("expected no payments before today, got %+v", statement)
+	}
+
+	expectError(t, f.tenant.do(http.MethodGet, path, nil), http.StatusForbidden, models.Forbidden)
+
+	for _, invalid := range []string{
+		"/portal/statement?from=" + testDate(0).String() + "&to=" + testDate(-1).String(),
+		"/portal/statement?from=" + testDate(-400).String() + "&to=" + testDate(0).String(),
+	} {
+		body := expectError(t, f.landlord.do(http.MethodGet, invalid, nil), http.StatusBadRequest, models.InvalidRequest)
+
+		if body.Field == nil || *body.Field != "to" {
+			t.Errorf("expected the error to be reported against to, got %v", body.Field)
+		}
+	}
+}
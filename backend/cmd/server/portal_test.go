@@ -0,0 +1,268 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func createPortalUser(t *testing.T, c testClient, payload models.CreatePortalUser) models.PortalUser {
+	t.Helper()
+
+	return expect[models.PortalUser](t, c.do(http.MethodPost, "/portal-users", payload), http.StatusCreated)
+}
+
+// portalFixture is a staff member's organisation with a tenanted property, and portal
+// users for its landlord and tenant
+type portalFixture struct {
+	staff    testClient
+	landlord testClient
+	tenant   testClient
+
+	property models.Property
+	tenancy  models.Tenant
+}
+
+func newPortalFixture(t *testing.T) portalFixture {
+	t.Helper()
+
+	f := portalFixture{
+		staff:    newStaffClient(t),
+		landlord: newPortalClient(t),
+		tenant:   newPortalClient(t),
+	}
+
+	landlord := createLandlord(t, f.staff, "Jane Citizen")
+	f.property = createProperty(t, f.staff, *landlord.Id, nil)
+	f.tenancy = createTenant(t, f.staff, *f.property.Id, "Alex Renter")
+
+	// a property the portal users shouldn't see
+	otherLandlord := createLandlord(t, f.staff, "John Smith")
+	otherProperty := createProperty(t, f.staff, *otherLandlord.Id, nil)
+	createTenant(t, f.staff, *otherProperty.Id, "Sam Renter")
+
+	createPortalUser(t, f.staff, models.CreatePortalUser{UserId: f.landlord.userID, LandlordId: landlord.Id})
+	createPortalUser(t, f.staff, models.CreatePortalUser{UserId: f.tenant.userID, TenantId: f.tenancy.Id})
+
+	return f
+}
+
+func TestPortalUsers(t *testing.T) {
+	f := newPortalFixture(t)
+
+	list := expect[models.PortalUserList](t, f.staff.do(http.MethodGet, "/portal-users", nil), http.StatusOK)
+
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 portal users, got %d", len(list.Items))
+	}
+
+	var revoked models.PortalUser
+
+	for _, portalUser := range list.Items {
+		if portalUser.UserId == f.landlord.userID {
+			revoked = portalUser
+		}
+	}
+
+	expect[models.PortalUser](t, f.staff.do(http.MethodDelete, "/portal-users/"+revoked.Id.String(), nil), http.StatusOK)
+
+	expectError(t, f.landlord.do(http.MethodGet, "/portal/me", nil), http.StatusForbidden, models.Forbidden)
+	expectError(t, f.staff.do(http.MethodDelete, "/portal-users/"+revoked.Id.String(), nil), http.StatusNotFound, models.NotFound)
+}
+
+func TestPortalUsersErrors(t *testing.T) {
+	f := newPortalFixture(t)
+
+	rec := f.staff.do(http.MethodPost, "/portal-users", models.CreatePortalUser{UserId: "user_1", LandlordId: &f.property.LandlordId, TenantId: f.tenancy.Id})
+	expectError(t, rec, http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	rec = f.staff.do(http.MethodPost, "/portal-users", models.CreatePortalUser{UserId: f.tenant.userID, TenantId: f.tenancy.Id})
+	expectError(t, rec, http.StatusConflict, models.Conflict)
+
+	// a property ID isn't a landlord ID
+	rec = f.staff.do(http.MethodPost, "/portal-users", models.CreatePortalUser{UserId: "user_1", LandlordId: f.property.Id})
+	expectError(t, rec, http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	// only admins can grant and revoke portal access
+	list := expect[models.PortalUserList](t, f.staff.do(http.MethodGet, "/portal-users", nil), http.StatusOK)
+
+	member := f.staff
+	member.role = "org:member"
+
+	rec = member.do(http.MethodPost, "/portal-users", models.CreatePortalUser{UserId: "user_1", LandlordId: &f.property.LandlordId})
+	expectError(t, rec, http.StatusForbidden, models.Forbidden)
+
+	expectError(t, member.do(http.MethodDelete, "/portal-users/"+list.Items[0].Id.String(), nil), http.StatusForbidden, models.Forbidden)
+}
+
+func TestPortalLandlord(t *testing.T) {
+	f := newPortalFixture(t)
+
+	expect[models.Organisation](t, f.staff.do(http.MethodPatch, "/organisation", map[string]any{
+		"trading_name": "Harbour Property Co",
+		"branding":     map[string]any{"primary_colour": "#1A2B3C"},
+	}), http.StatusOK)
+
+	me := expect[models.PortalIdentity](t, f.landlord.do(http.MethodGet, "/portal/me", nil), http.StatusOK)
+
+	if me.Role != models.PortalRoleLandlord || me.Landlord == nil || *me.Landlord.Id != f.property.LandlordId {
+		t.Errorf("expected the landlord's identity, got %+v", me)
+	}
+
+	if me.Agency.TradingName == nil || me.Agency.Branding.PrimaryColour == nil || *me.Agency.Branding.PrimaryColour != "#1A2B3C" {
+		t.Errorf("expected the agency's trading name and branding, got %+v", me.Agency)
+	}
+
+	properties := expect[models.PortalPropertyList](t, f.landlord.do(http.MethodGet, "/portal/properties", nil), http.StatusOK)
+
+	if len(properties.Items) != 1 || properties.Items[0].Id != *f.property.Id {
+		t.Errorf("expected only the landlord's property, got %+v", properties.Items)
+	}
+
+	tenancies := expect[models.PortalTenancyList](t, f.landlord.do(http.MethodGet, "/portal/tenancies", nil), http.StatusOK)
+
+	if len(tenancies.Items) != 1 || tenancies.Items[0].Id != *f.tenancy.Id {
+		t.Errorf("expected only the tenancy of the landlord's property, got %+v", tenancies.Items)
+	}
+
+	rec := f.landlord.do(http.MethodPost, "/portal/maintenance-requests", models.CreateMaintenanceRequest{Title: "Leaking tap", Description: "The kitchen tap drips"})
+	expectError(t, rec, http.StatusForbidden, models.Forbidden)
+}
+
+func TestPortalTenant(t *testing.T) {
+	f := newPortalFixture(t)
+
+	me := expect[models.PortalIdentity](t, f.tenant.do(http.MethodGet, "/portal/me", nil), http.StatusOK)
+
+	if me.Role != models.PortalRoleTenant || me.Tenant == nil || *me.Tenant.Id != *f.tenancy.Id {
+		t.Errorf("expected the tenant's identity, got %+v", me)
+	}
+
+	properties := expect[models.PortalPropertyList](t, f.tenant.do(http.MethodGet, "/portal/properties", nil), http.StatusOK)
+
+	if len(properties.Items) != 1 || properties.Items[0].Id != *f.property.Id {
+		t.Errorf("expected only the tenant's property, got %+v", properties.Items)
+	}
+
+	tenancies := expect[models.PortalTenancyList](t, f.tenant.do(http.MethodGet, "/portal/tenancies", nil), http.StatusOK)
+
+	if len(tenancies.Items) != 1 || tenancies.Items[0].Id != *f.tenancy.Id {
+		t.Errorf("expected only the tenant's own tenancy, got %+v", tenancies.Items)
+	}
+}
+
+func TestPortalWithoutAccess(t *testing.T) {
+	c := newPortalClient(t)
+
+	for _, path := range []string{"/portal/me", "/portal/properties", "/portal/tenancies", "/portal/maintenance-requests"} {
+		expectError(t, c.do(http.MethodGet, path, nil), http.StatusForbidden, models.Forbidden)
+	}
+}
+
+func TestMaintenanceRequests(t *testing.T) {
+	f := newPortalFixture(t)
+
+	rec := f.tenant.do(http.MethodPost, "/portal/maintenance-requests", models.CreateMaintenanceRequest{Title: "Leaking tap", Description: "The kitchen tap drips"})
+	created := expect[models.MaintenanceRequest](t, rec, http.StatusCreated)
+
+	if created.PropertyId != *f.property.Id || created.Status != models.Open {
+		t.Errorf("expected an open request against the tenant's property, got %+v", created)
+	}
+
+	for _, c := range []testClient{f.tenant, f.landlord} {
+		list := expect[models.MaintenanceRequestList](t, c.do(http.MethodGet, "/portal/maintenance-requests", nil), http.StatusOK)
+
+		if len(list.Items) != 1 || *list.Items[0].Id != *created.Id {
+			t.Errorf("expected the portal user to see the request, got %+v", list.Items)
+		}
+	}
+
+	path := "/maintenance-requests/" + created.Id.String()
+
+	updated := expect[models.MaintenanceRequest](t, f.staff.do(http.MethodPatch, path, map[string]any{"status": models.InProgress}), http.StatusOK)
+
+	if updated.Status != models.InProgress {
+		t.Errorf("expected status %q, got %q", models.InProgress, updated.Status)
+	}
+
+	list := expect[models.MaintenanceRequestList](t, f.staff.do(http.MethodGet, "/maintenance-requests?status=in_progress", nil), http.StatusOK)
+
+	if len(list.Items) != 1 {
+		t.Errorf("expected 1 request in progress, got %d", len(list.Items))
+	}
+
+	list = expect[models.MaintenanceRequestList](t, f.staff.do(http.MethodGet, "/maintenance-requests?status=open", nil), http.StatusOK)
+
+	if len(list.Items) != 0 {
+		t.Errorf("expected no open requests, got %d", len(list.Items))
+	}
+
+	other := newStaffClient(t)
+
+	expectError(t, other.do(http.MethodPatch, path, map[string]any{"status": models.Completed}), http.StatusNotFound, models.NotFound)
+}
+
+func TestPortalEndedTenancy(t *testing.T) {
+	payload := models.CreateMaintenanceRequest{Title: "Leaking tap", Description: "The kitchen tap drips"}
+
+	for _, tt := range []struct {
+		name string
+		end  func(t *testing.T, f portalFixture, path string)
+	}{
+		{"vacated", func(t *testing.T, f portalFixture, path string) {
+			expect[models.Tenant](t, f.staff.do(http.MethodPatch, path, map[string]any{"vacate_date": testDate(-1)}), http.StatusOK)
+		}},
+		{"archived", func(t *testing.T, f portalFixture, path string) {
+			expect[models.Tenant](t, f.staff.do(http.MethodDelete, path, nil), http.StatusOK)
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPortalFixture(t)
+
+			tt.end(t, f, "/tenants/"+f.tenancy.Id.String())
+
+			properties := expect[models.PortalPropertyList](t, f.tenant.do(http.MethodGet, "/portal/properties", nil), http.StatusOK)
+
+			if len(properties.Items) != 0 {
+				t.Errorf("expected the tenant to no longer see the property, got %+v", properties.Items)
+			}
+
+			expectError(t, f.tenant.do(http.MethodPost, "/portal/maintenance-requests", payload), http.StatusForbidden, models.Forbidden)
+		})
+	}
+}
+
+func TestPortalOrganisations(t *testing.T) {
+	f := newPortalFixture(t)
+
+	// the tenant also rents from another agency
+	other := newStaffClient(t)
+	landlord := createLandlord(t, other, "John Smith")
+	property := createProperty(t, other, *landlord.Id, nil)
+	tenancy := createTenant(t, other, *property.Id, "Alex Renter")
+	createPortalUser(t, other, models.CreatePortalUser{UserId: f.tenant.userID, TenantId: tenancy.Id})
+
+	organisations := expect[models.PortalOrganisationList](t, f.tenant.do(http.MethodGet, "/portal/organisations", nil), http.StatusOK)
+
+	if len(organisations.Items) != 2 {
+		t.Fatalf("expected access in 2 organisations, got %+v", organisations.Items)
+	}
+
+	expectError(t, f.tenant.do(http.MethodGet, "/portal/me", nil), http.StatusBadRequest, models.InvalidRequest)
+
+	for _, c := range []struct {
+		orgID   string
+		tenancy models.Tenant
+	}{{f.staff.orgID, f.tenancy}, {other.orgID, tenancy}} {
+		rec := f.tenant.doWithHeaders(http.MethodGet, "/portal/me", nil, map[string]string{"Portal-Organisation": c.orgID})
+		me := expect[models.PortalIdentity](t, rec, http.StatusOK)
+
+		if me.OrganisationId != c.orgID || me.Tenant == nil || *me.Tenant.Id != *c.tenancy.Id {
+			t.Errorf("expected the tenancy in %s, got %+v", c.orgID, me)
+		}
+	}
+
+	rec := f.tenant.doWithHeaders(http.MethodGet, "/portal/me", nil, map[string]string{"Portal-Organisation": "org_other"})
+	expectError(t, rec, http.StatusForbidden, models.Forbidden)
+}
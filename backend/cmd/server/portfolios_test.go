@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func TestPortfolios(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	tenanted := createProperty(t, c, *landlord.Id, &c.userID)
+	createProperty(t, c, *landlord.Id, &c.userID)
+	createProperty(t, c, *landlord.Id, nil)
+	createTenant(t, c, *tenanted.Id, "Alex Renter")
+
+	want := models.PortfolioSummary{
+		UserId:           c.userID,
+		Properties:       2,
+		VacantProperties: 1,
+		ActiveTenancies:  1,
+	}
+
+	for _, userID := range []string{"me", c.userID} {
+		got := expect[models.PortfolioSummary](t, c.do(http.MethodGet, "/portfolios/"+userID, nil), http.StatusOK)
+
+		if got != want {
+			t.Errorf("GET /portfolios/%s: expected %+v, got %+v", userID, want, got)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// testDate returns a date the given number of days from today
+func testDate(days int) openapi_types.Date {
+	return openapi_types.Date{Time: time.Now().AddDate(0, 0, days)}
+}
+
+func createProperty(t *testing.T, c testClient, landlordID openapi_types.UUID, assignedTo *string) models.Property {
+	t.Helper()
+
+	managementFee := 5.5
+
+	rec := c.do(http.MethodPost, "/properties", models.CreateProperty{
+		LandlordId:       landlordID,
+		StreetNumber:     "10",
+		StreetName:       "Pitt Street",
+		Suburb:           "Sydney",
+		State:            "NSW",
+		Postcode:         "2000",
+		Country:          "Australia",
+		ManagementFee:    &managementFee,
+		ManagementGained: testDate(-365),
+		AssignedTo:       assignedTo,
+	})
+
+	return expect[models.Property](t, rec, http.StatusCreated)
+}
+
+func TestProperties(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	created := createProperty(t, c, *landlord.Id, nil)
+
+	path := "/properties/" + created.Id.String()
+
+	got := expect[models.Property](t, c.do(http.MethodGet, path, nil), http.StatusOK)
+
+	if got.LandlordId != *landlord.Id {
+		t.Errorf("expected landlord %s, got %s", landlord.Id, got.LandlordId)
+	}
+
+	list := expect[models.PropertyList](t, c.do(http.MethodGet, "/properties?address=pitt", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 {
+		t.Errorf("expected the address filter to match 1 property, got %d", list.Pagination.Total)
+	}
+
+	updated := expect[models.Property](t, c.do(http.MethodPatch, path, map[string]any{"street_number": "12"}), http.StatusOK)
+
+	if updated.StreetNumber != "12" {
+		t.Errorf("expected street number %q, got %q", "12", updated.StreetNumber)
+	}
+
+	archived := expect[models.Property](t, c.do(http.MethodDelete, path, nil), http.StatusOK)
+
+	if archived.IsArchived == nil {
+		t.Error("expected the property to be archived")
+	}
+}
+
+func TestPropertiesSortNulls(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	nulls := map[openapi_types.UUID]bool{}
+
+	for i := range 4 {
+		property := createProperty(t, c, *landlord.Id, nil)
+
+		// management_gained is required by the API but nullable in older records
+		if i%2 == 0 {
+			if _, err := testPool.Exec(context.Background(), `UPDATE properties SET management_gained = NULL WHERE id = $1`, *property.Id); err != nil {
+				t.Fatalf("clearing management_gained: %v", err)
+			}
+
+			nulls[*property.Id] = true
+		}
+	}
+
+	for _, sort := range []string{"management_gained", "-management_gained"} {
+		t.Run(sort, func(t *testing.T) {
+			path := "/properties?limit=1&sort=" + sort
+			list := expect[models.PropertyList](t, c.do(http.MethodGet, path, nil), http.StatusOK)
+			seen := []openapi_types.UUID{*list.Items[0].Id}
+
+			for list.Cursors.Next != nil {
+				list = expect[models.PropertyList](t, c.do(http.MethodGet, path+"&after="+*list.Cursors.Next, nil), http.StatusOK)
+				seen = append(seen, *list.Items[0].Id)
+			}
+
+			if len(seen) != 4 {
+				t.Fatalf("expected to page through all 4 properties, got %v", seen)
+			}
+
+			if nulls[seen[0]] || nulls[seen[1]] || !nulls[seen[2]] || !nulls[seen[3]] {
+				t.Errorf("expected the properties without management_gained last, got %v", seen)
+			}
+
+			for i := len(seen) - 2; i >= 0; i-- {
+				list = expect[models.PropertyList](t, c.do(http.MethodGet, path+"&before="+*list.Cursors.Previous, nil), http.StatusOK)
+
+				if *list.Items[0].Id != seen[i] {
+					t.Fatalf("expected paging back to return %s, got %s", seen[i], *list.Items[0].Id)
+				}
+			}
+		})
+	}
+}
+
+func TestPropertiesAssignedTo(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	createProperty(t, c, *landlord.Id, &c.userID)
+	createProperty(t, c, *landlord.Id, nil)
+
+	list := expect[models.PropertyList](t, c.do(http.MethodGet, "/properties?assigned_to=me", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 || *list.Items[0].AssignedTo != c.userID {
+		t.Errorf("expected 1 property assigned to the current user, got %+v", list.Items)
+	}
+
+	// an empty assignee would match nothing, rather than the unassigned properties
+	for _, path := range []string{"/properties?assigned_to=", "/tenants?assigned_to="} {
+		expectError(t, c.do(http.MethodGet, path, nil), http.StatusBadRequest, models.InvalidRequest)
+	}
+}
+
+func TestPropertiesAssigneeMembership(t *testing.T) {
+	c := newStaffClient(t)
+	other := newStaffClient(t)
+
+	colleague := "user_" + uuid.NewString()
+	testMembers.add(c.orgID, colleague)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, &colleague)
+
+	path := "/properties/" + property.Id.String()
+
+	// a member of another organisation
+	err := expectError(t, c.do(http.MethodPatch, path, models.UpdateProperty{AssignedTo: nullable.NewNullableWithValue(other.userID)}), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if err.Field == nil || *err.Field != "assigned_to" {
+		t.Errorf("expected the error to locate assigned_to, got %v", err.Field)
+	}
+
+	payload := models.CreateProperty{
+		LandlordId:       *landlord.Id,
+		StreetNumber:     "2",
+		StreetName:       "George Street",
+		Suburb:           "Sydney",
+		State:            "NSW",
+		Postcode:         "2000",
+		Country:          "Australia",
+		ManagementGained: testDate(-30),
+		AssignedTo:       &other.userID,
+	}
+
+	expectError(t, c.do(http.MethodPost, "/properties", payload), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	updated := expect[models.Property](t, c.do(http.MethodPatch, path, models.UpdateProperty{AssignedTo: nullable.NewNullNullable[string]()}), http.StatusOK)
+
+	if updated.AssignedTo != nil {
+		t.Errorf("expected the property to be unassigned, got %s", *updated.AssignedTo)
+	}
+}
+
+func TestPropertiesErrors(t *testing.T) {
+	c := newStaffClient(t)
+
+	expectError(t, c.do(http.MethodGet, "/properties/not-a-uuid", nil), http.StatusBadRequest, models.InvalidId)
+	expectError(t, c.do(http.MethodPatch, "/properties/00000000-0000-0000-0000-000000000000", map[string]any{"street_number": "1"}), http.StatusNotFound, models.NotFound)
+
+	// the landlord has to belong to the same organisation
+	other := newStaffClient(t)
+	landlord := createLandlord(t, other, "Jane Citizen")
+	managementFee := 5.5
+
+	rec := c.do(http.MethodPost, "/properties", models.CreateProperty{
+		LandlordId:       *landlord.Id,
+		ManagementFee:    &managementFee,
+		StreetNumber:     "10",
+		StreetName:       "Pitt Street",
+		Suburb:           "Sydney",
+		State:            "NSW",
+		Postcode:         "2000",
+		Country:          "Australia",
+		ManagementGained: testDate(0),
+	})
+
+	body := expectError(t, rec, http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if body.Field == nil || *body.Field != "landlord_id" {
+		t.Errorf("expected the error to be reported against landlord_id, got %v", body.Field)
+	}
+}
+
+func TestNestedLists(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	other := createLandlord(t, c, "John Smith")
+	property := createProperty(t, c, *landlord.Id, nil)
+	createProperty(t, c, *other.Id, nil)
+	tenant := createTenant(t, c, *property.Id, "Alex Renter")
+
+	properties := expect[models.PropertyList](t, c.do(http.MethodGet, "/landlords/"+landlord.Id.String()+"/properties", nil), http.StatusOK)
+
+	if len(properties.Items) != 1 || *properties.Items[0].Id != *property.Id {
+		t.Errorf("expected only the landlord's property, got %+v", properties.Items)
+	}
+
+	tenants := expect[models.TenantList](t, c.do(http.MethodGet, "/properties/"+property.Id.String()+"/tenants", nil), http.StatusOK)
+
+	if len(tenants.Items) != 1 || *tenants.Items[0].Id != *tenant.Id {
+		t.Errorf("expected only the property's tenant, got %+v", tenants.Items)
+	}
+
+	expectError(t, c.do(http.MethodGet, "/landlords/00000000-0000-0000-0000-000000000000/properties", nil), http.StatusNotFound, models.NotFound)
+}
+
+func TestPropertiesConditionalGet(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+
+	revalidate := func(path string, headers map[string]string) int {
+		t.Helper()
+
+		return c.doWithHeaders(http.MethodGet, path, nil, headers).Code
+	}
+
+	rec := c.do(http.MethodGet, "/properties?expand=landlord", nil)
+	listTag := rec.Header().Get("ETag")
+	expect[models.PropertyList](t, rec, http.StatusOK)
+
+	if !strings.HasPrefix(listTag, `W/"`) {
+		t.Fatalf("expected a weak ETag for the list, got %q", listTag)
+	}
+
+	if code := revalidate("/properties?expand=landlord", map[string]string{"If-None-Match": listTag}); code != http.StatusNotModified {
+		t.Errorf("expected 304 for an unchanged list, got %d", code)
+	}
+
+	// the same records, but a different page, order or fieldset
+	for _, other := range []string{"?expand=landlord&page=2", "?expand=landlord&sort=-street_name", "?expand=landlord&fields=suburb"} {
+		if code := revalidate("/properties"+other, map[string]string{"If-None-Match": listTag}); code != http.StatusOK {
+			t.Errorf("expected 200 for %s with the first page's ETag, got %d", other, code)
+		}
+	}
+
+	path := "/properties/" + property.Id.String()
+
+	rec = c.do(http.MethodGet, path, nil)
+	lastModified := rec.Header().Get("Last-Modified")
+	expect[models.Property](t, rec, http.StatusOK)
+
+	if code := revalidate(path, map[string]string{"If-Modified-Since": lastModified}); code != http.StatusNotModified {
+		t.Errorf("expected 304 for an unmodified property, got %d", code)
+	}
+
+	expect[models.Landlord](t, c.do(http.MethodPatch, "/landlords/"+landlord.Id.String(), map[string]any{"name": "Jane Doe"}), http.StatusOK)
+
+	if code := revalidate("/properties?expand=landlord", map[string]string{"If-None-Match": listTag}); code != http.StatusOK {
+		t.Errorf("expected 200 once an expanded landlord changed, got %d", code)
+	}
+
+	rec = c.do(http.MethodGet, "/properties", nil)
+	listTag = rec.Header().Get("ETag")
+	expect[models.PropertyList](t, rec, http.StatusOK)
+
+	createProperty(t, c, *landlord.Id, nil)
+
+	if code := revalidate("/properties", map[string]string{"If-None-Match": listTag}); code != http.StatusOK {
+		t.Errorf("expected 200 once a property was added, got %d", code)
+	}
+}
+
+func TestPropertiesBatch(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	staleTag := c.do(http.MethodGet, "/properties/"+property.Id.String(), nil).Header().Get("ETag")
+	missingLandlord := openapi_types.UUID(uuid.MustParse("00000000-0000-0000-0000-000000000000"))
+
+	newProperty := func(landlordID openapi_types.UUID) models.CreateProperty {
+		return models.CreateProperty{
+			LandlordId:       landlordID,
+			StreetNumber:     "20",
+			StreetName:       "Pitt Street",
+			Suburb:           "Sydney",
+			State:            "NSW",
+			Postcode:         "2000",
+			Country:          "Australia",
+			ManagementGained: testDate(-30),
+		}
+	}
+
+	streetNumber := "12"
+	batch := map[string]any{
+		"create": []models.CreateProperty{newProperty(*landlord.Id), newProperty(missingLandlord)},
+		"update": []models.PropertyBatchUpdate{{Id: *property.Id, Changes: models.UpdateProperty{StreetNumber: &streetNumber}}},
+	}
+
+	body := expectError(t, c.do(http.MethodPost, "/properties/batch", batch), http.StatusUnprocessableEntity, models.ValidationFailed)
+
+	if body.Field == nil || *body.Field != "create[1].landlord_id" {
+		t.Errorf("expected the error to locate the failed create, got %v", body.Field)
+	}
+
+	list := expect[models.PropertyList](t, c.do(http.MethodGet, "/properties", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 || list.Items[0].StreetNumber != "10" {
+		t.Errorf("expected a failed atomic batch to change nothing, got %+v", list.Items)
+	}
+
+	batch["atomic"] = false
+	results := expect[models.PropertyBatchResults](t, c.do(http.MethodPost, "/properties/batch", batch), http.StatusOK)
+
+	if results.Succeeded != 2 || results.Failed != 1 {
+		t.Fatalf("expected 2 operations to succeed and 1 to fail, got %+v", results)
+	}
+
+	if results.Create[0].Status != http.StatusCreated || results.Create[1].Status != http.StatusUnprocessableEntity || results.Create[1].Error == nil {
+		t.Errorf("expected the second create to fail, got %+v", results.Create)
+	}
+
+	if results.Update[0].Property == nil || results.Update[0].Property.StreetNumber != "12" || results.Update[0].Etag == nil {
+		t.Errorf("expected the update to succeed with an ETag, got %+v", results.Update[0])
+	}
+
+	// the update changed the property since the tag was read
+	archive := map[string]any{
+		"archive": []models.BatchArchive{{Id: *property.Id, IfMatch: &staleTag}},
+	}
+
+	expectError(t, c.do(http.MethodPost, "/properties/batch", archive), http.StatusPreconditionFailed, models.PreconditionFailed)
+}
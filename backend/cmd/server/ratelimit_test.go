@@ -0,0 +1,55 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/config"
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	c := newStaffClient(t)
+
+	deps := testDeps
+	deps.limiter = ratelimit.NewLimiter()
+
+	handler := newHandler(deps, &config.Config{
+		Env:            "TEST",
+		RequestTimeout: 30 * time.Second,
+		RateLimits: map[ratelimit.Class]ratelimit.Limits{
+			ratelimit.Read: {User: ratelimit.Limit{Requests: 2, Period: time.Hour}},
+		},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)), fakeAuthenticate)
+
+	do := func(method string, path string, body any) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, c.newRequest(method, path, body))
+		return rec
+	}
+
+	for _, remaining := range []string{"1", "0"} {
+		rec := do(http.MethodGet, "/landlords", nil)
+		expect[models.LandlordList](t, rec, http.StatusOK)
+
+		if got := rec.Header().Get("RateLimit-Remaining"); got != remaining {
+			t.Errorf("expected %s requests remaining, got %q", remaining, got)
+		}
+	}
+
+	rec := do(http.MethodGet, "/landlords", nil)
+	expectError(t, rec, http.StatusTooManyRequests, models.RateLimited)
+
+	if rec.Header().Get("Retry-After") == "" || rec.Header().Get("RateLimit-Limit") != "2" {
+		t.Errorf("expected Retry-After and RateLimit-Limit headers, got %v", rec.Header())
+	}
+
+	// the limit isn't shared with other users in the organisation
+	c.userID = "user_other"
+	expect[models.LandlordList](t, do(http.MethodGet, "/landlords", nil), http.StatusOK)
+}
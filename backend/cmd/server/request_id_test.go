@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/middleware"
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func TestRequestID(t *testing.T) {
+	c := newStaffClient(t)
+
+	t.Run("generated when not sent", func(t *testing.T) {
+		rec := c.do(http.MethodGet, "/landlords", nil)
+
+		if rec.Header().Get(middleware.RequestIDHeader) == "" {
+			t.Errorf("expected a %s header", middleware.RequestIDHeader)
+		}
+	})
+
+	t.Run("echoed in the response and error body", func(t *testing.T) {
+		req := c.newRequest(http.MethodGet, "/landlords/not-a-uuid", nil)
+		req.Header.Set(middleware.RequestIDHeader, "client-request-1")
+
+		rec := httptest.NewRecorder()
+		testHandler.ServeHTTP(rec, req)
+
+		body := expectError(t, rec, http.StatusBadRequest, models.InvalidId)
+
+		if got := rec.Header().Get(middleware.RequestIDHeader); got != "client-request-1" {
+			t.Errorf("expected the request ID to be echoed, got %q", got)
+		}
+
+		if body.RequestId == nil || *body.RequestId != "client-request-1" {
+			t.Errorf("expected the request ID in the error body, got %v", body.RequestId)
+		}
+	})
+
+	t.Run("replaced when malformed", func(t *testing.T) {
+		req := c.newRequest(http.MethodGet, "/landlords", nil)
+		req.Header.Set(middleware.RequestIDHeader, "not a valid id\n")
+
+		rec := httptest.NewRecorder()
+		testHandler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get(middleware.RequestIDHeader); got == "" || got == "not a valid id\n" {
+			t.Errorf("expected a generated request ID, got %q", got)
+		}
+	})
+}
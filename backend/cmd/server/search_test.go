@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func TestSearch(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	tenant := createTenant(t, c, *property.Id, "Alex Renter")
+
+	results := expect[models.SearchResults](t, c.do(http.MethodGet, "/search?q=citizen", nil), http.StatusOK)
+
+	if len(results.Items) == 0 || results.Items[0].Id != landlord.Id.String() || results.Items[0].Field != models.Name {
+		t.Fatalf("expected the landlord's name to be the best match, got %+v", results.Items)
+	}
+
+	want := []models.HighlightSegment{{Text: "Jane ", Matched: false}, {Text: "Citizen", Matched: true}}
+
+	if got := results.Items[0].Highlight; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected highlight %+v, got %+v", want, got)
+	}
+
+	// close matches are found as well as exact ones
+	results = expect[models.SearchResults](t, c.do(http.MethodGet, "/search?q=citisen", nil), http.StatusOK)
+
+	if len(results.Items) == 0 || results.Items[0].Id != landlord.Id.String() {
+		t.Errorf("expected a misspelt name to match the landlord, got %+v", results.Items)
+	}
+
+	results = expect[models.SearchResults](t, c.do(http.MethodGet, "/search?q=0411+111&type=tenant", nil), http.StatusOK)
+
+	if len(results.Items) != 1 || results.Items[0].Id != tenant.Id.String() || results.Items[0].Field != models.Mobile {
+		t.Errorf("expected the tenant's mobile to match, got %+v", results.Items)
+	}
+
+	results = expect[models.SearchResults](t, c.do(http.MethodGet, "/search?q=pitt+street&type=property", nil), http.StatusOK)
+
+	if len(results.Items) != 1 || results.Items[0].Type != models.SearchResultTypeProperty {
+		t.Errorf("expected only the property to match, got %+v", results.Items)
+	}
+
+	expectError(t, c.do(http.MethodGet, "/search?q=+a+", nil), http.StatusBadRequest, models.InvalidRequest)
+}
@@ -3,35 +3,75 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
 	"github.com/davidtaing/property-management/api"
 	"github.com/davidtaing/property-management/internal/config"
+	"github.com/davidtaing/property-management/internal/health"
+	"github.com/davidtaing/property-management/internal/logging"
+	"github.com/davidtaing/property-management/internal/members"
+	"github.com/davidtaing/property-management/internal/metrics"
 	"github.com/davidtaing/property-management/internal/middleware"
+	"github.com/davidtaing/property-management/internal/ratelimit"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/davidtaing/property-management/internal/tracing"
+	"github.com/getkin/kin-openapi/openapi3"
 	oapifilter "github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5/pgxpool"
 	oapiMiddleware "github.com/oapi-codegen/nethttp-middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 )
 
+// Set at build time with -ldflags "-X main.gitSHA=... -X main.buildTime=..."
+var (
+	gitSHA    string
+	buildTime string
+)
+
 func main() {
-	logger := setupLogger()
 	config, err := config.NewConfig()
 
 	if err != nil {
-		logger.Error(err.Error())
+		slog.Error(err.Error())
 		os.Exit(1)
 	}
 
+	logger := setupLogger(config)
+
 	clerk.SetKey(config.ClerkKey)
 
-	dbpool, err := pgxpool.New(context.Background(), config.DatabaseURL)
+	buildInfo := health.NewBuildInfo(gitSHA, buildTime)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), config, buildInfo.GitSHA)
+
+	if err != nil {
+		logger.Error("Unable to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(config.DatabaseURL)
+
+	if err != nil {
+		logger.Error("Unable to parse DATABASE_URL")
+		os.Exit(1)
+	}
+
+	// Postgres cancels any statement that runs longer than the query timeout
+	poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.QueryTimeout.Milliseconds(), 10)
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	dbpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 
 	if err != nil {
 		logger.Error("Unable to connect to the database")
@@ -58,14 +98,122 @@ func main() {
 
 	swagger.Servers = nil
 
-	server := api.NewServer(dbpool, logger)
+	repositories := storage.NewPostgresRepositories(dbpool)
+	server := api.NewServer(repositories, members.NewClerkDirectory(), logger)
+
+	healthHandler, err := health.NewHandler(dbpool, buildInfo, logger)
+
+	if err != nil {
+		logger.Error("Unable to load migrations", "error", err)
+		os.Exit(1)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewPoolCollector(dbpool),
+		metrics.NewStatsCollector(storage.NewPostgresStatsRepository(dbpool), logger),
+	)
+
+	h := newHandler(handlerDeps{
+		server:  server,
+		health:  healthHandler,
+		metrics: metrics.NewHTTP(registry),
+		tiers:   metrics.NewTierCache(repositories.Organisations, logger),
+		swagger: swagger,
+		limiter: ratelimit.NewLimiter(),
+	}, config, logger, clerkhttp.WithHeaderAuthorization())
+
+	s := &http.Server{
+		Handler:      h,
+		Addr:         config.Addr(),
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	metricsServer := &http.Server{
+		Handler:     metricsMux,
+		Addr:        config.MetricsAddr(),
+		ReadTimeout: config.ReadTimeout,
+		IdleTimeout: config.IdleTimeout,
+	}
 
+	// Fly sends SIGTERM before stopping a machine, SIGINT is for local development
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 2)
+
+	go func() {
+		logger.Info("Starting server", "addr", s.Addr)
+		serverErr <- s.ListenAndServe()
+	}()
+
+	go func() {
+		logger.Info("Starting metrics server", "addr", metricsServer.Addr)
+		serverErr <- metricsServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		logger.Error("Server failed", "error", err)
+		dbpool.Close()
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down, waiting for in-flight requests", "timeout", config.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Unable to drain connections before the shutdown timeout", "error", err)
+	}
+
+	// Stopped last so requests that finish while draining are still scraped
+	metricsServer.Shutdown(shutdownCtx)
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Unable to flush traces", "error", err)
+	}
+
+	// The pool is closed by the deferred dbpool.Close once requests have drained
+	logger.Info("Server stopped")
+}
+
+// handlerDeps are the parts the API handler is built from
+type handlerDeps struct {
+	server  *api.Server
+	health  *health.Handler
+	metrics *metrics.HTTP
+	tiers   middleware.TierResolver
+	swagger *openapi3.T
+	limiter *ratelimit.Limiter
+}
+
+// newHandler builds the API handler. authenticate verifies the caller and adds their
+// session claims to the request context, and is replaced in tests. The health
+// endpoints are served without authentication or request validation.
+func newHandler(deps handlerDeps, config *config.Config, logger *slog.Logger, authenticate func(http.Handler) http.Handler) http.Handler {
 	r := mux.NewRouter()
 
+	r.Use(tracing.Middleware())
+	r.Use(middleware.RequestIDMiddleware(logger))
+	r.Use(middleware.MetricsMiddleware(deps.metrics, deps.tiers))
 	r.Use(middleware.LoggingMiddleware(logger))
+	r.Use(middleware.TimeoutMiddleware(config.RequestTimeout))
 	r.Use(middleware.AuthMiddleware())
+	r.Use(middleware.RateLimitMiddleware(deps.limiter, config.RateLimits))
 
-	validatorOptions := &oapiMiddleware.Options{}
+	validatorOptions := &oapiMiddleware.Options{
+		ErrorHandler: api.HandleValidationError,
+	}
 
 	// stub this out and use the clerkhttp middleware instead
 	validatorOptions.Options.AuthenticationFunc = func(c context.Context, input *oapifilter.AuthenticationInput) error {
@@ -74,34 +222,32 @@ func main() {
 
 	// Use our validation middleware to check all requests against the
 	// OpenAPI schema.
-	r.Use(oapiMiddleware.OapiRequestValidatorWithOptions(swagger, validatorOptions))
-
-	h := api.HandlerFromMux(server, r)
+	r.Use(oapiMiddleware.OapiRequestValidatorWithOptions(deps.swagger, validatorOptions))
 
-	c := setupCors(config, logger)
-	h = clerkhttp.WithHeaderAuthorization()(h)
-	h = c.Handler(h)
+	h := api.HandlerWithOptions(deps.server, api.GorillaServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: api.HandleParamError,
+	})
 
-	s := &http.Server{
-		Handler: h,
-		Addr:    "0.0.0.0:8080",
-	}
+	root := mux.NewRouter()
+	deps.health.Register(root)
+	root.PathPrefix("/").Handler(authenticate(h))
 
-	logger.Info("Starting server on port 8080")
+	c := setupCors(config, logger)
 
-	log.Fatal(s.ListenAndServe())
+	return c.Handler(root)
 }
 
-func setupLogger() *slog.Logger {
+func setupLogger(config *config.Config) *slog.Logger {
 	level := slog.LevelDebug
 
-	if os.Getenv("ENV") == "PRODUCTION" {
+	if config.Env == "PRODUCTION" {
 		level = slog.LevelInfo
 	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(logging.NewRedactingHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: level,
-	}))
+	}), config.LogRedactFields))
 
 	return logger
 }
@@ -116,10 +262,19 @@ func setupCors(config *config.Config, logger *slog.Logger) *cors.Cors {
 	logger.Debug("Allowed origins", "origins", allowedOrigins)
 
 	c := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type", "Accept"},
-		ExposedHeaders:   []string{"Authorization"},
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "Accept", "If-Match", "If-None-Match", "If-Modified-Since", "Idempotency-Key", "Portal-Organisation", middleware.RequestIDHeader},
+		ExposedHeaders: []string{
+			"Authorization",
+			middleware.RequestIDHeader,
+			"Retry-After",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"ETag",
+			"Idempotent-Replayed",
+		},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func createTenant(t *testing.T, c testClient, propertyID openapi_types.UUID, name string) models.Tenant {
+	t.Helper()
+
+	rec := c.do(http.MethodPost, "/tenants", models.CreateTenant{
+		PropertyId:        propertyID,
+		Name:              name,
+		Email:             "tenant@example.com",
+		Mobile:            "0411111111",
+		RentalAmount:      650,
+		Frequency:         "weekly",
+		OriginalStartDate: testDate(-180),
+		StartDate:         testDate(-180),
+		EndDate:           testDate(185),
+		PaidTo:            testDate(7),
+	})
+
+	return expect[models.Tenant](t, rec, http.StatusCreated)
+}
+
+func TestTenants(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	created := createTenant(t, c, *property.Id, "Alex Renter")
+
+	path := "/tenants/" + created.Id.String()
+
+	got := expect[models.Tenant](t, c.do(http.MethodGet, path, nil), http.StatusOK)
+
+	if got.PropertyId != *property.Id {
+		t.Errorf("expected property %s, got %s", property.Id, got.PropertyId)
+	}
+
+	list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?name=alex", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 {
+		t.Errorf("expected the name filter to match 1 tenant, got %d", list.Pagination.Total)
+	}
+
+	updated := expect[models.Tenant](t, c.do(http.MethodPatch, path, map[string]any{"rental_amount": 700}), http.StatusOK)
+
+	if updated.RentalAmount != 700 {
+		t.Errorf("expected rental amount 700, got %v", updated.RentalAmount)
+	}
+
+	archived := expect[models.Tenant](t, c.do(http.MethodDelete, path, nil), http.StatusOK)
+
+	if archived.IsArchived == nil {
+		t.Error("expected the tenant to be archived")
+	}
+}
+
+func TestTenantsAssignedTo(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	assigned := createProperty(t, c, *landlord.Id, &c.userID)
+	unassigned := createProperty(t, c, *landlord.Id, nil)
+	createTenant(t, c, *assigned.Id, "Alex Renter")
+	createTenant(t, c, *unassigned.Id, "Sam Renter")
+
+	list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?assigned_to=me", nil), http.StatusOK)
+
+	if list.Pagination.Total != 1 || list.Items[0].PropertyId != *assigned.Id {
+		t.Errorf("expected 1 tenant of a property assigned to the current user, got %+v", list.Items)
+	}
+}
+
+func TestTenantsErrors(t *testing.T) {
+	c := newStaffClient(t)
+
+	expectError(t, c.do(http.MethodDelete, "/tenants/not-a-uuid", nil), http.StatusBadRequest, models.InvalidId)
+	expectError(t, c.do(http.MethodGet, "/tenants/00000000-0000-0000-0000-000000000000", nil), http.StatusNotFound, models.NotFound)
+
+	rec := c.do(http.MethodPost, "/tenants", models.CreateTenant{
+		PropertyId:        openapi_types.UUID{},
+		Name:              "Alex Renter",
+		Email:             "tenant@example.com",
+		Mobile:            "0411111111",
+		RentalAmount:      650,
+		Frequency:         "weekly",
+		OriginalStartDate: testDate(0),
+		StartDate:         testDate(0),
+		EndDate:           testDate(365),
+		PaidTo:            testDate(0),
+	})
+
+	expectError(t, rec, http.StatusUnprocessableEntity, models.ValidationFailed)
+}
+
+func TestTenantsSort(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+
+	for i, name := range []string{"Alex", "Blair", "Casey"} {
+		tenant := createTenant(t, c, *property.Id, name)
+
+		// Casey is paid to the earliest date, and so is the furthest in arrears
+		paidTo := testDate(-10 * i)
+		c.do(http.MethodPatch, "/tenants/"+tenant.Id.String(), map[string]any{"paid_to": paidTo})
+	}
+
+	list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?sort=paid_to,name&limit=2", nil), http.StatusOK)
+
+	if len(list.Items) != 2 || list.Items[0].Name != "Casey" || list.Items[1].Name != "Blair" {
+		t.Fatalf("expected the tenants furthest in arrears first, got %+v", list.Items)
+	}
+
+	next := *list.Cursors.Next
+	list = expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?sort=paid_to,name&limit=2&after="+next, nil), http.StatusOK)
+
+	if len(list.Items) != 1 || list.Items[0].Name != "Alex" {
+		t.Errorf("expected the next page in the same order, got %+v", list.Items)
+	}
+
+	list = expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?sort=-name", nil), http.StatusOK)
+
+	if len(list.Items) != 3 || list.Items[0].Name != "Casey" {
+		t.Errorf("expected the tenants in descending name order, got %+v", list.Items)
+	}
+
+	expect[models.Error](t, c.do(http.MethodGet, "/tenants?sort=mobile", nil), http.StatusBadRequest)
+	expectError(t, c.do(http.MethodGet, "/tenants?sort=name,-name", nil), http.StatusBadRequest, models.InvalidRequest)
+	// the cursor was issued for a different order
+	expectError(t, c.do(http.MethodGet, "/tenants?sort=name&after="+next, nil), http.StatusBadRequest, models.InvalidRequest)
+}
+
+func TestTenantsFilters(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	otherProperty := createProperty(t, c, *landlord.Id, nil)
+
+	createTenant(t, c, *property.Id, "Alex")
+	monthly := createTenant(t, c, *otherProperty.Id, "Blair")
+	c.do(http.MethodPatch, "/tenants/"+monthly.Id.String(), map[string]any{
+		"frequency":     "monthly",
+		"paid_to":       testDate(-14),
+		"rental_amount": 2800,
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"set", "frequency=fortnightly,monthly", []string{"Blair"}},
+		{"set of IDs", "property_id=" + property.Id.String(), []string{"Alex"}},
+		{"date range", "paid_to_to=" + testDate(-1).String(), []string{"Blair"}},
+		{"open date range", "paid_to_from=" + testDate(-14).String(), []string{"Alex", "Blair"}},
+		{"amount range", "rental_amount_min=1000&rental_amount_max=3000", []string{"Blair"}},
+		{"combined", "frequency=weekly&rental_amount_min=1000", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?"+tt.query, nil), http.StatusOK)
+
+			names := []string{}
+
+			for _, tenant := range list.Items {
+				names = append(names, tenant.Name)
+			}
+
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, names)
+			}
+		})
+	}
+
+	expect[models.Error](t, c.do(http.MethodGet, "/tenants?frequency=daily", nil), http.StatusBadRequest)
+	expect[models.Error](t, c.do(http.MethodGet, "/tenants?end_date_from=not-a-date", nil), http.StatusBadRequest)
+}
+
+func TestTenantsExpand(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+	property := createProperty(t, c, *landlord.Id, nil)
+	tenant := createTenant(t, c, *property.Id, "Alex Renter")
+
+	got := expect[models.Tenant](t, c.do(http.MethodGet, "/tenants/"+tenant.Id.String()+"?expand=property.landlord", nil), http.StatusOK)
+
+	if got.Property == nil || *got.Property.Id != *property.Id {
+		t.Fatalf("expected the tenant's property to be included, got %+v", got.Property)
+	}
+
+	if got.Property.Landlord == nil || got.Property.Landlord.Name != "Jane Citizen" {
+		t.Errorf("expected the property's landlord to be included, got %+v", got.Property.Landlord)
+	}
+
+	list := expect[models.TenantList](t, c.do(http.MethodGet, "/tenants?expand=property", nil), http.StatusOK)
+
+	if len(list.Items) != 1 || list.Items[0].Property == nil || list.Items[0].Property.Landlord != nil {
+		t.Errorf("expected only the property to be included, got %+v", list.Items)
+	}
+
+	expectError(t, c.do(http.MethodGet, "/tenants?expand=landlord", nil), http.StatusBadRequest, models.InvalidRequest)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	c := newStaffClient(t)
+
+	landlord := createLandlord(t, c, "Jane Citizen")
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req := c.newRequest(http.MethodGet, "/landlords/"+landlord.Id.String(), nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	rec := httptest.NewRecorder()
+	testHandler.ServeHTTP(rec, req)
+
+	expect[map[string]any](t, rec, http.StatusOK)
+
+	var server, queries int
+
+	for _, span := range testSpans.Ended() {
+		if span.SpanContext().TraceID().String() != traceID {
+			continue
+		}
+
+		switch span.SpanKind() {
+		case trace.SpanKindServer:
+			server++
+
+			if !strings.Contains(span.Name(), "/landlords/{id}") {
+				t.Errorf("expected the request span to be named after the route template, got %q", span.Name())
+			}
+		case trace.SpanKindClient:
+			queries++
+		}
+	}
+
+	if server != 1 || queries == 0 {
+		t.Errorf("expected a request span and query spans in the caller's trace, got %d and %d", server, queries)
+	}
+}
@@ -3,15 +3,120 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/davidtaing/property-management/internal/ratelimit"
 	"github.com/joho/godotenv"
 )
 
+const (
+	defaultHost = "0.0.0.0"
+	defaultPort = "8080"
+
+	defaultMetricsPort = "9091"
+
+	defaultRequestTimeout  = 30 * time.Second
+	defaultQueryTimeout    = 10 * time.Second
+	defaultReadTimeout     = 15 * time.Second
+	defaultWriteTimeout    = 45 * time.Second
+	defaultIdleTimeout     = 120 * time.Second
+	defaultShutdownTimeout = 25 * time.Second
+
+	defaultTraceSampleRatio = 1.0
+)
+
+// defaultRateLimits allow for a frontend polling a few lists while someone works
+// through a form. Exports are expensive, so only a handful are allowed a minute.
+var defaultRateLimits = map[ratelimit.Class]ratelimit.Limits{
+	ratelimit.Read: {
+		Organisation: ratelimit.Limit{Requests: 1200, Period: time.Minute},
+		User:         ratelimit.Limit{Requests: 300, Period: time.Minute},
+	},
+	ratelimit.Write: {
+		Organisation: ratelimit.Limit{Requests: 300, Period: time.Minute},
+		User:         ratelimit.Limit{Requests: 60, Period: time.Minute},
+	},
+	ratelimit.Export: {
+		Organisation: ratelimit.Limit{Requests: 30, Period: time.Minute},
+		User:         ratelimit.Limit{Requests: 10, Period: time.Minute},
+	},
+	ratelimit.Batch: {
+		Organisation: ratelimit.Limit{Requests: 20, Period: time.Minute},
+		User:         ratelimit.Limit{Requests: 5, Period: time.Minute},
+	},
+}
+
+// defaultLogRedactFields are the personal details of landlords, tenants and portal
+// users. name and address also cover the list endpoints' search params, and q the
+// search endpoint's, which is usually a name, email or mobile.
+var defaultLogRedactFields = []string{
+	"q",
+	"name",
+	"email",
+	"mobile",
+	"phone",
+	"address",
+	"address_line_1",
+	"address_line_2",
+	"street_number",
+	"street_name",
+	"full_address",
+}
+
+// Trace exporters
+const (
+	TracesExporterNone   = "none"
+	TracesExporterOTLP   = "otlp"
+	TracesExporterStdout = "stdout"
+)
+
 type Config struct {
 	Env         string
 	DatabaseURL string
 	ClerkKey    string
+
+	// RequestTimeout is the deadline for handling a request
+	RequestTimeout time.Duration
+	// QueryTimeout is the deadline for a single database statement
+	QueryTimeout time.Duration
+
+	Host string
+	Port string
+	// MetricsPort serves /metrics separately from the API so it isn't public
+	MetricsPort string
+
+	// ReadTimeout, WriteTimeout and IdleTimeout are passed to the http.Server.
+	// WriteTimeout should be longer than RequestTimeout so timed out requests still
+	// get a response.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout is how long in-flight requests are given to finish on shutdown
+	ShutdownTimeout time.Duration
+
+	// TracesExporter is where spans are sent: none, otlp or stdout
+	TracesExporter string
+	// OTLPEndpoint is the URL of the collector spans are sent to with the otlp
+	// exporter, e.g. http://localhost:4318
+	OTLPEndpoint string
+	// TraceSampleRatio is the fraction of new traces that are recorded. Requests that
+	// are part of a sampled trace are always recorded.
+	TraceSampleRatio float64
+
+	// LogRedactFields are the fields masked in logs, wherever they appear in a log
+	// line. Setting LOG_REDACT_FIELDS replaces the defaults.
+	LogRedactFields []string
+
+	// RateLimits are the limits for each route class, set with variables such as
+	// RATE_LIMIT_READ_ORGANISATION and RATE_LIMIT_WRITE_USER in the form 300/1m, or
+	// off. Classes without limits aren't limited. Each machine counts requests in
+	// its own memory, so with requests spread across N machines a caller gets up to
+	// N times the limit. Divide the limits by the machine count when scaling out.
+	RateLimits map[ratelimit.Class]ratelimit.Limits
 }
 
 func NewConfig() (*Config, error) {
@@ -37,11 +142,167 @@ func NewConfig() (*Config, error) {
 		return nil, fmt.Errorf("CLERK_KEY is not set")
 	}
 
+	host := os.Getenv("HOST")
+
+	if host == "" {
+		host = defaultHost
+	}
+
+	// PORT is set by Fly
+	port := os.Getenv("PORT")
+
+	if port == "" {
+		port = defaultPort
+	}
+
+	metricsPort := os.Getenv("METRICS_PORT")
+
+	if metricsPort == "" {
+		metricsPort = defaultMetricsPort
+	}
+
+	// The variable names follow the OpenTelemetry SDK conventions
+	tracesExporter := os.Getenv("OTEL_TRACES_EXPORTER")
+
+	switch tracesExporter {
+	case "":
+		tracesExporter = TracesExporterNone
+	case TracesExporterNone, TracesExporterOTLP, TracesExporterStdout:
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER must be one of none, otlp or stdout, got %q", tracesExporter)
+	}
+
+	traceSampleRatio := defaultTraceSampleRatio
+
+	if value := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be a number between 0 and 1, got %q", value)
+		}
+
+		traceSampleRatio = ratio
+	}
+
+	logRedactFields := defaultLogRedactFields
+
+	if value := os.Getenv("LOG_REDACT_FIELDS"); value != "" {
+		logRedactFields = nil
+
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				logRedactFields = append(logRedactFields, field)
+			}
+		}
+	}
+
 	config := &Config{
-		DatabaseURL: databaseURL,
-		Env:         env,
-		ClerkKey:    clerkKey,
+		DatabaseURL:      databaseURL,
+		Env:              env,
+		ClerkKey:         clerkKey,
+		Host:             host,
+		Port:             port,
+		MetricsPort:      metricsPort,
+		TracesExporter:   tracesExporter,
+		OTLPEndpoint:     os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		TraceSampleRatio: traceSampleRatio,
+		LogRedactFields:  logRedactFields,
+	}
+
+	timeouts := []struct {
+		key      string
+		fallback time.Duration
+		value    *time.Duration
+	}{
+		{"REQUEST_TIMEOUT", defaultRequestTimeout, &config.RequestTimeout},
+		{"QUERY_TIMEOUT", defaultQueryTimeout, &config.QueryTimeout},
+		{"READ_TIMEOUT", defaultReadTimeout, &config.ReadTimeout},
+		{"WRITE_TIMEOUT", defaultWriteTimeout, &config.WriteTimeout},
+		{"IDLE_TIMEOUT", defaultIdleTimeout, &config.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", defaultShutdownTimeout, &config.ShutdownTimeout},
+	}
+
+	rateLimits, err := rateLimitsFromEnv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	config.RateLimits = rateLimits
+
+	for _, timeout := range timeouts {
+		d, err := durationFromEnv(timeout.key, timeout.fallback)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*timeout.value = d
 	}
 
 	return config, nil
 }
+
+// Addr is the address the server listens on
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// MetricsAddr is the address the metrics server listens on
+func (c *Config) MetricsAddr() string {
+	return net.JoinHostPort(c.Host, c.MetricsPort)
+}
+
+// durationFromEnv parses a duration such as 30s or 500ms, falling back to the default
+// when the variable isn't set
+func durationFromEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+
+	if value == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 30s, got %q", key, value)
+	}
+
+	return d, nil
+}
+
+// rateLimitsFromEnv overrides the default rate limits with any that are set
+func rateLimitsFromEnv() (map[ratelimit.Class]ratelimit.Limits, error) {
+	rateLimits := map[ratelimit.Class]ratelimit.Limits{}
+
+	for class, limits := range defaultRateLimits {
+		scopes := []struct {
+			name  string
+			limit *ratelimit.Limit
+		}{
+			{"ORGANISATION", &limits.Organisation},
+			{"USER", &limits.User},
+		}
+
+		for _, scope := range scopes {
+			key := "RATE_LIMIT_" + strings.ToUpper(string(class)) + "_" + scope.name
+			value := os.Getenv(key)
+
+			if value == "" {
+				continue
+			}
+
+			limit, err := ratelimit.ParseLimit(value)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+
+			*scope.limit = limit
+		}
+
+		rateLimits[class] = limits
+	}
+
+	return rateLimits, nil
+}
@@ -0,0 +1,158 @@
+// Package health serves the liveness, readiness and build info endpoints probed by Fly.
+// They sit outside the API so they don't need a session token or appear in the schema.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/davidtaing/property-management/migrations"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// checkTimeout bounds the database checks so a stuck database fails the probe
+// rather than hanging it
+const checkTimeout = 3 * time.Second
+
+const unknown = "unknown"
+
+// BuildInfo identifies the running build
+type BuildInfo struct {
+	GitSHA    string `json:"git_sha"`
+	BuildTime string `json:"build_time"`
+}
+
+// NewBuildInfo uses the values set with -ldflags, falling back to the VCS details Go
+// stamps into the binary when it's built from a checkout
+func NewBuildInfo(gitSHA string, buildTime string) BuildInfo {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && gitSHA == "":
+				gitSHA = setting.Value
+			case setting.Key == "vcs.time" && buildTime == "":
+				buildTime = setting.Value
+			}
+		}
+	}
+
+	if gitSHA == "" {
+		gitSHA = unknown
+	}
+
+	if buildTime == "" {
+		buildTime = unknown
+	}
+
+	return BuildInfo{GitSHA: gitSHA, BuildTime: buildTime}
+}
+
+type Handler struct {
+	dbpool     *pgxpool.Pool
+	migrations *goose.Provider
+	build      BuildInfo
+	logger     *slog.Logger
+}
+
+func NewHandler(dbpool *pgxpool.Pool, build BuildInfo, logger *slog.Logger) (*Handler, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, stdlib.OpenDBFromPool(dbpool), migrations.FS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		dbpool:     dbpool,
+		migrations: provider,
+		build:      build,
+		logger:     logger,
+	}, nil
+}
+
+// Register adds the endpoints to the router
+func (h *Handler) Register(r *mux.Router) {
+	r.HandleFunc("/healthz", h.Live).Methods(http.MethodGet)
+	r.HandleFunc("/readyz", h.Ready).Methods(http.MethodGet)
+	r.HandleFunc("/version", h.Version).Methods(http.MethodGet)
+}
+
+type status struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type version struct {
+	BuildInfo
+	// SchemaVersion is the latest applied migration, null when the database can't be reached
+	SchemaVersion *int64 `json:"schema_version"`
+}
+
+// Live reports that the process is up and serving requests
+func (h *Handler) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, status{Status: "ok"})
+}
+
+// Ready reports whether the database can be reached and is fully migrated
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	checks := map[string]string{
+		"database":   "ok",
+		"migrations": "ok",
+	}
+
+	code := http.StatusOK
+	resp := status{Status: "ok", Checks: checks}
+
+	if err := h.dbpool.Ping(ctx); err != nil {
+		h.logger.Warn("Readiness check failed", "check", "database", "error", err)
+		checks["database"] = "unreachable"
+		checks["migrations"] = "unknown"
+		code = http.StatusServiceUnavailable
+	} else if pending, err := h.migrations.HasPending(ctx); err != nil {
+		h.logger.Warn("Readiness check failed", "check", "migrations", "error", err)
+		checks["migrations"] = "unknown"
+		code = http.StatusServiceUnavailable
+	} else if pending {
+		checks["migrations"] = "pending"
+		code = http.StatusServiceUnavailable
+	}
+
+	if code != http.StatusOK {
+		resp.Status = "unavailable"
+	}
+
+	writeJSON(w, code, resp)
+}
+
+// Version reports the build and the schema version of the database
+func (h *Handler) Version(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	resp := version{BuildInfo: h.build}
+
+	schemaVersion, err := h.migrations.GetDBVersion(ctx)
+
+	if err != nil {
+		h.logger.Warn("Unable to read the schema version", "error", err)
+	} else {
+		resp.SchemaVersion = &schemaVersion
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,51 @@
+// Package logging carries a request-scoped logger in the context, so every log line
+// for a request can be correlated by its request ID
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type contextKey struct{}
+
+// requestLogger is shared by everything handling the request, so attributes added by
+// a later middleware also appear in the lines logged by an earlier one
+type requestLogger struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+// NewContext stores the request's logger in the context
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestLogger{logger: logger})
+}
+
+// FromContext returns the request's logger, or fallback when there isn't one
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	rl, ok := ctx.Value(contextKey{}).(*requestLogger)
+
+	if !ok {
+		return fallback
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.logger
+}
+
+// With adds attributes to the request's logger
+func With(ctx context.Context, args ...any) {
+	rl, ok := ctx.Value(contextKey{}).(*requestLogger)
+
+	if !ok {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.logger = rl.logger.With(args...)
+}
@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Redacted replaces the value of sensitive fields
+const Redacted = "[REDACTED]"
+
+// redactingHandler masks sensitive fields before records reach the next handler.
+// Structs, maps and slices, such as the models logged by the handlers, are converted
+// to their JSON form so fields are matched on the same names used by the API.
+type redactingHandler struct {
+	next   slog.Handler
+	fields map[string]bool
+}
+
+// NewRedactingHandler wraps next so the value of any attribute, or nested field, whose
+// name is in fields is logged as [REDACTED]. Names are matched case-insensitively.
+func NewRedactingHandler(next slog.Handler, fields []string) slog.Handler {
+	sensitive := make(map[string]bool, len(fields))
+
+	for _, field := range fields {
+		sensitive[strings.ToLower(field)] = true
+	}
+
+	return &redactingHandler{next: next, fields: sensitive}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(a)
+	}
+
+	return &redactingHandler{next: h.next.WithAttrs(redacted), fields: h.fields}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name), fields: h.fields}
+}
+
+func (h *redactingHandler) redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+
+	if h.fields[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+
+		for i, ga := range group {
+			redacted[i] = h.redactAttr(ga)
+		}
+
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		return slog.Any(a.Key, h.redactAny(a.Value.Any()))
+	default:
+		return a
+	}
+}
+
+func (h *redactingHandler) redactAny(v any) any {
+	// errors are logged by their message, which the handlers don't fill with
+	// personal details. The database's are the exception for data exceptions, whose
+	// messages quote the value it rejected.
+	if err, ok := v.(error); ok {
+		return redactError(err)
+	}
+
+	if v == nil {
+		return v
+	}
+
+	t := reflect.TypeOf(v)
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return v
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		// it can't be inspected, so it can't be shown to be safe
+		return Redacted
+	}
+
+	var decoded any
+
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return Redacted
+	}
+
+	return h.redactJSON(decoded)
+}
+
+func (h *redactingHandler) redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if h.fields[strings.ToLower(key)] {
+				v[key] = Redacted
+			} else {
+				v[key] = h.redactJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = h.redactJSON(value)
+		}
+	}
+
+	return v
+}
+
+// redactError replaces a database data exception, class 22, with its SQLSTATE and the
+// column it's for, so the value it rejected isn't logged. Other errors are logged as
+// they are.
+func redactError(err error) any {
+	var pgErr *pgconn.PgError
+
+	if !errors.As(err, &pgErr) || !strings.HasPrefix(pgErr.Code, "22") {
+		return err
+	}
+
+	redacted := "data exception (SQLSTATE " + pgErr.Code
+
+	if pgErr.ColumnName != "" {
+		redacted += ", column " + pgErr.ColumnName
+	}
+
+	return redacted + ")"
+}
@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type contact struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Email  string  `json:"email"`
+	Mobile *string `json:"mobile,omitempty"`
+}
+
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	mobile := "0400 000 000"
+	logger := slog.New(NewRedactingHandler(slog.NewJSONHandler(&buf, nil), []string{"name", "Email", "mobile"}))
+
+	logger.With("email", "a@example.com").WithGroup("request").Info("test",
+		"id", "abc",
+		"contact", &contact{ID: "abc", Name: "Jane", Email: "jane@example.com", Mobile: &mobile},
+		"contacts", []contact{{ID: "def", Name: "John"}},
+		"query", url.Values{"name": {"Jane"}, "page": {"2"}},
+		slog.Group("nested", "NAME", "Jane", "limit", 10),
+	)
+
+	var line map[string]any
+
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("decoding log line: %v: %s", err, buf.String())
+	}
+
+	request := line["request"].(map[string]any)
+	contactLine := request["contact"].(map[string]any)
+	query := request["query"].(map[string]any)
+	nested := request["nested"].(map[string]any)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"attribute added with With", line["email"], Redacted},
+		{"unrelated attribute", request["id"], "abc"},
+		{"struct field", contactLine["name"], Redacted},
+		{"pointer struct field", contactLine["mobile"], Redacted},
+		{"unrelated struct field", contactLine["id"], "abc"},
+		{"slice element field", request["contacts"].([]any)[0].(map[string]any)["name"], Redacted},
+		{"query param", query["name"], Redacted},
+		{"unrelated query param", query["page"], []any{"2"}},
+		{"group attribute, case-insensitive", nested["NAME"], Redacted},
+		{"unrelated group attribute", nested["limit"], float64(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotJSON, wantJSON := mustJSON(t, tt.got), mustJSON(t, tt.want); gotJSON != wantJSON {
+				t.Errorf("expected %s, got %s", wantJSON, gotJSON)
+			}
+		})
+	}
+
+	if bytes.Contains(buf.Bytes(), []byte("Jane")) || bytes.Contains(buf.Bytes(), []byte("example.com")) {
+		t.Errorf("expected personal details to be redacted: %s", buf.String())
+	}
+}
+
+func TestRedactingHandlerDataException(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(NewRedactingHandler(slog.NewJSONHandler(&buf, nil), nil))
+
+	logger.Error("test",
+		"error", fmt.Errorf("creating tenant: %w", &pgconn.PgError{
+			Code:       "22P02",
+			Message:    `invalid input syntax for type uuid: "jane@example.com"`,
+			ColumnName: "property_id",
+		}),
+		"other", &pgconn.PgError{Severity: "ERROR", Code: "23505", Message: `duplicate key value violates unique constraint "tenants_pkey"`},
+	)
+
+	var line map[string]any
+
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("decoding log line: %v: %s", err, buf.String())
+	}
+
+	if want := "data exception (SQLSTATE 22P02, column property_id)"; line["error"] != want {
+		t.Errorf("expected %q, got %q", want, line["error"])
+	}
+
+	if want := `ERROR: duplicate key value violates unique constraint "tenants_pkey" (SQLSTATE 23505)`; line["other"] != want {
+		t.Errorf("expected other errors to be logged as they are, %q, got %q", want, line["other"])
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		t.Fatalf("encoding %v: %v", v, err)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,39 @@
+// Package members looks up the users in an organisation. Memberships are kept by Clerk,
+// so they're looked up there rather than stored with the records.
+package members
+
+import (
+	"context"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/organizationmembership"
+)
+
+// Directory finds out whether users belong to an organisation
+type Directory interface {
+	IsMember(ctx context.Context, organisationID string, userID string) (bool, error)
+}
+
+// ClerkDirectory looks up memberships with Clerk's backend API, using the key set with
+// clerk.SetKey
+type ClerkDirectory struct{}
+
+func NewClerkDirectory() *ClerkDirectory {
+	return &ClerkDirectory{}
+}
+
+func (d *ClerkDirectory) IsMember(ctx context.Context, organisationID string, userID string) (bool, error) {
+	limit := int64(1)
+
+	memberships, err := organizationmembership.List(ctx, &organizationmembership.ListParams{
+		ListParams:     clerk.ListParams{Limit: &limit},
+		OrganizationID: organisationID,
+		UserIDs:        []string{userID},
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return memberships.TotalCount > 0, nil
+}
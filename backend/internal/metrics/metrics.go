@@ -0,0 +1,270 @@
+// Package metrics defines the Prometheus metrics exported on /metrics
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "property_management"
+
+// HTTP holds the request metrics recorded by middleware.MetricsMiddleware
+type HTTP struct {
+	Requests *prometheus.CounterVec
+	Duration *prometheus.HistogramVec
+}
+
+// HTTPLabels are the labels on the request metrics. route is the route template,
+// e.g. /landlords/{id}, so there isn't a series per record.
+var HTTPLabels = []string{"route", "method", "status", "tier"}
+
+func NewHTTP(reg prometheus.Registerer) *HTTP {
+	m := &HTTP{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests handled.",
+		}, HTTPLabels),
+		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, HTTPLabels),
+	}
+
+	reg.MustRegister(m.Requests, m.Duration)
+
+	return m
+}
+
+// tierCacheTTL is how long an organisation's tier is cached. Tiers rarely change, and
+// looking one up on every request would double the queries.
+const tierCacheTTL = 5 * time.Minute
+
+// tierFailureTTL is how long a failed lookup is cached, so a database outage doesn't
+// add a failing query to every request
+const tierFailureTTL = 30 * time.Second
+
+const tierLookupTimeout = time.Second
+
+// UnknownTier labels requests from organisations whose tier couldn't be looked up
+const UnknownTier = "unknown"
+
+// TierSource looks up the tier of an organisation
+type TierSource interface {
+	Tier(ctx context.Context, organisationID string) (string, error)
+}
+
+type tierEntry struct {
+	tier    string
+	expires time.Time
+	// loaded is closed once the tier has been looked up, so concurrent requests
+	// wait for the one lookup
+	loaded chan struct{}
+}
+
+// expired reports whether the entry has been looked up and is out of date
+func (e *tierEntry) expired() bool {
+	select {
+	case <-e.loaded:
+		return time.Now().After(e.expires)
+	default:
+		return false
+	}
+}
+
+// TierCache looks up the tier of an organisation, caching the result
+type TierCache struct {
+	organisations TierSource
+	logger        *slog.Logger
+
+	mu      sync.Mutex
+	entries map[string]*tierEntry
+}
+
+func NewTierCache(organisations TierSource, logger *slog.Logger) *TierCache {
+	return &TierCache{
+		organisations: organisations,
+		logger:        logger,
+		entries:       map[string]*tierEntry{},
+	}
+}
+
+// Tier returns the tier of the organisation. It's called after the request has been
+// handled, so the lookup isn't cancelled along with the request.
+func (c *TierCache) Tier(ctx context.Context, organisationID string) string {
+	c.mu.Lock()
+	entry, ok := c.entries[organisationID]
+
+	if ok && !entry.expired() {
+		c.mu.Unlock()
+		<-entry.loaded
+
+		return entry.tier
+	}
+
+	entry = &tierEntry{loaded: make(chan struct{})}
+	c.entries[organisationID] = entry
+	c.mu.Unlock()
+
+	defer close(entry.loaded)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tierLookupTimeout)
+	defer cancel()
+
+	tier, err := c.organisations.Tier(ctx, organisationID)
+
+	if err != nil {
+		c.logger.Warn("Unable to look up organisation tier", "organisation_id", organisationID, "error", err)
+		entry.tier, entry.expires = UnknownTier, time.Now().Add(tierFailureTTL)
+
+		return entry.tier
+	}
+
+	entry.tier, entry.expires = tier, time.Now().Add(tierCacheTTL)
+
+	return entry.tier
+}
+
+type poolCollector struct {
+	dbpool *pgxpool.Pool
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	emptyAcquireWait *prometheus.Desc
+}
+
+// NewPoolCollector exports the connection pool stats
+func NewPoolCollector(dbpool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		dbpool:           dbpool,
+		acquiredConns:    desc("acquired_connections", "Connections currently in use."),
+		idleConns:        desc("idle_connections", "Connections idle in the pool."),
+		totalConns:       desc("total_connections", "Connections open, including those being established."),
+		maxConns:         desc("max_connections", "Maximum size of the pool."),
+		acquireCount:     desc("acquires_total", "Connections acquired from the pool."),
+		acquireDuration:  desc("acquire_duration_seconds_total", "Time spent acquiring connections from the pool."),
+		emptyAcquireWait: desc("empty_acquire_wait_seconds_total", "Time spent waiting for a connection because the pool was empty."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireWait
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.dbpool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireWait, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+}
+
+// statsTimeout bounds the stats query so a slow database doesn't hang the scrape
+const statsTimeout = 5 * time.Second
+
+// statsCacheTTL is how long the stats are served from memory. The query aggregates
+// every tenancy, so scrapes within this of the last one don't repeat it.
+const statsCacheTTL = time.Minute
+
+type statsCollector struct {
+	stats  storage.StatsRepository
+	logger *slog.Logger
+
+	// mu is held while the stats are refreshed, so concurrent scrapes share the query
+	mu        sync.Mutex
+	cached    []storage.TenancyStats
+	refreshed time.Time
+
+	activeTenancies    *prometheus.Desc
+	tenanciesInArrears *prometheus.Desc
+	arrearsAmount      *prometheus.Desc
+}
+
+// NewStatsCollector exports business totals across every organisation, labelled by
+// tier and currency, as amounts in different currencies can't be added up. They're
+// queried at most once every statsCacheTTL.
+func NewStatsCollector(stats storage.StatsRepository, logger *slog.Logger) prometheus.Collector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, []string{"tier", "currency"}, nil)
+	}
+
+	return &statsCollector{
+		stats:              stats,
+		logger:             logger,
+		activeTenancies:    desc("active_tenancies", "Tenancies that haven't been archived or vacated."),
+		tenanciesInArrears: desc("tenancies_in_arrears", "Active tenancies with rent paid to a date before today."),
+		arrearsAmount:      desc("arrears_amount", "Rent owed by active tenancies in arrears, in the currency of the label."),
+	}
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeTenancies
+	ch <- c.tenanciesInArrears
+	ch <- c.arrearsAmount
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.tenancyStats()
+
+	if err != nil {
+		c.logger.Error("Unable to collect tenancy stats", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.activeTenancies, err)
+		return
+	}
+
+	for _, s := range stats {
+		ch <- prometheus.MustNewConstMetric(c.activeTenancies, prometheus.GaugeValue, float64(s.ActiveTenancies), s.Tier, s.Currency)
+		ch <- prometheus.MustNewConstMetric(c.tenanciesInArrears, prometheus.GaugeValue, float64(s.TenanciesInArrears), s.Tier, s.Currency)
+		ch <- prometheus.MustNewConstMetric(c.arrearsAmount, prometheus.GaugeValue, s.ArrearsAmount, s.Tier, s.Currency)
+	}
+}
+
+// tenancyStats returns the cached stats, querying them again once they're older than
+// statsCacheTTL. Failures aren't cached, so the next scrape tries again.
+func (c *statsCollector) tenancyStats() ([]storage.TenancyStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached != nil && time.Since(c.refreshed) < statsCacheTTL {
+		return c.cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.stats.TenancyStats(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cached, c.refreshed = stats, time.Now()
+
+	return stats, nil
+}
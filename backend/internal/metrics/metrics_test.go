@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeTiers returns the tier, or err, counting the lookups
+type fakeTiers struct {
+	tier    string
+	err     error
+	lookups atomic.Int32
+}
+
+func (f *fakeTiers) Tier(ctx context.Context, organisationID string) (string, error) {
+	f.lookups.Add(1)
+
+	return f.tier, f.err
+}
+
+func TestTierCache(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("cached", func(t *testing.T) {
+		source := &fakeTiers{tier: "premium"}
+		cache := NewTierCache(source, logger)
+
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if got := cache.Tier(context.Background(), "org_1"); got != "premium" {
+					t.Errorf("expected premium, got %s", got)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if n := source.lookups.Load(); n != 1 {
+			t.Errorf("expected the tier to be looked up once, got %d", n)
+		}
+	})
+
+	t.Run("failures cached as unknown", func(t *testing.T) {
+		source := &fakeTiers{err: errors.New("connection refused")}
+		cache := NewTierCache(source, logger)
+
+		for range 3 {
+			if got := cache.Tier(context.Background(), "org_1"); got != UnknownTier {
+				t.Errorf("expected %s, got %s", UnknownTier, got)
+			}
+		}
+
+		if n := source.lookups.Load(); n != 1 {
+			t.Errorf("expected the failure to be cached, got %d lookups", n)
+		}
+	})
+}
+
+// fakeStats returns the stats, or err, counting the queries
+type fakeStats struct {
+	err     error
+	queries atomic.Int32
+}
+
+func (f *fakeStats) TenancyStats(ctx context.Context) ([]storage.TenancyStats, error) {
+	f.queries.Add(1)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []storage.TenancyStats{{Tier: "standard", Currency: "AUD", ActiveTenancies: 3, TenanciesInArrears: 1, ArrearsAmount: 650}}, nil
+}
+
+func TestStatsCollector(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("cached", func(t *testing.T) {
+		source := &fakeStats{}
+		collector := NewStatsCollector(source, logger)
+
+		for range 3 {
+			if n := testutil.CollectAndCount(collector); n != 3 {
+				t.Errorf("expected 3 metrics, got %d", n)
+			}
+		}
+
+		if n := source.queries.Load(); n != 1 {
+			t.Errorf("expected the stats to be queried once, got %d", n)
+		}
+	})
+
+	t.Run("failures not cached", func(t *testing.T) {
+		source := &fakeStats{err: errors.New("connection refused")}
+		collector := NewStatsCollector(source, logger)
+
+		for range 2 {
+			if _, err := testutil.CollectAndLint(collector); err == nil {
+				t.Error("expected the failed collection to be reported")
+			}
+		}
+
+		if n := source.queries.Load(); n != 2 {
+			t.Errorf("expected each scrape to retry the query, got %d", n)
+		}
+	})
+}
@@ -4,24 +4,33 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/clerk/clerk-sdk-go/v2"
-	"github.com/davidtaing/property-management/api"
+	"github.com/davidtaing/property-management/internal/logging"
+	"github.com/davidtaing/property-management/internal/models"
 	"github.com/davidtaing/property-management/internal/types"
 	"github.com/gorilla/mux"
 )
 
+// PortalPathPrefix is the restricted API used by landlords and tenants. Portal users
+// aren't members of an organisation, their access is resolved from their portal identity.
+const PortalPathPrefix = "/portal/"
+
 func AuthMiddleware() mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			claims, ok := clerk.SessionClaimsFromContext(r.Context())
+			requestID, _ := r.Context().Value(types.RequestIDKey).(string)
 
 			if !ok {
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusUnauthorized)
-				json.NewEncoder(w).Encode(api.Error{
-					Code:    http.StatusUnauthorized,
-					Message: "Unauthorized",
+				json.NewEncoder(w).Encode(models.Error{
+					Code:      http.StatusUnauthorized,
+					ErrorCode: models.Unauthorized,
+					Message:   "Unauthorized",
+					RequestId: &requestID,
 				})
 				return
 			}
@@ -30,10 +39,24 @@ func AuthMiddleware() mux.MiddlewareFunc {
 			orgRole := claims.ActiveOrganizationRole
 			userId := claims.Subject
 
+			if org == "" && !strings.HasPrefix(r.URL.Path, PortalPathPrefix) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(models.Error{
+					Code:      http.StatusForbidden,
+					ErrorCode: models.Forbidden,
+					Message:   "An active organisation is required",
+					RequestId: &requestID,
+				})
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), types.OrgIDKey, org)
 			ctx = context.WithValue(ctx, types.OrgRoleKey, orgRole)
 			ctx = context.WithValue(ctx, types.UserIDKey, userId)
 
+			logging.With(ctx, "org_id", org, "user_id", userId)
+
 			r = r.WithContext(ctx)
 
 			// Call the next handler
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/davidtaing/property-management/internal/logging"
 	"github.com/gorilla/mux"
 )
 
@@ -23,22 +24,13 @@ func LoggingMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
 			// Call the next handler
 			next.ServeHTTP(wrapper, r)
 
-			queryValues := r.URL.Query()
-			for key, values := range queryValues {
-				if key == "name" || key == "address" {
-					for i := range values {
-						values[i] = "[REDACTED]"
-					}
-				}
-			}
-			query := queryValues.Encode()
-
 			// Log response details
 			duration := time.Since(start)
-			logger.Info("HTTP Request",
+			logging.FromContext(r.Context(), logger).Info("HTTP Request",
 				"method", r.Method,
 				"path", r.URL.Path,
-				"query", query,
+				// logged as an object so search params are redacted by name
+				"query", r.URL.Query(),
 				"status", wrapper.statusCode,
 				"duration_ms", duration.Milliseconds(),
 				"remote_addr", r.RemoteAddr,
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/davidtaing/property-management/internal/metrics"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// noTier labels requests that aren't made on behalf of an organisation, e.g. from the portal
+const noTier = "none"
+
+// TierResolver looks up the tier used to label an organisation's requests
+type TierResolver interface {
+	Tier(ctx context.Context, organisationID string) string
+}
+
+// Records the count and duration of requests, labelled by the route template, status
+// and the tier of the caller's organisation
+func MetricsMiddleware(m *metrics.HTTP, tiers TierResolver) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapper := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			start := time.Now()
+
+			next.ServeHTTP(wrapper, r)
+
+			duration := time.Since(start)
+
+			route := "unknown"
+
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+
+			method, status := r.Method, strconv.Itoa(wrapper.statusCode)
+
+			record := func(tier string) {
+				labels := prometheus.Labels{
+					"route":  route,
+					"method": method,
+					"status": status,
+					"tier":   tier,
+				}
+
+				m.Requests.With(labels).Inc()
+				m.Duration.With(labels).Observe(duration.Seconds())
+			}
+
+			claims, ok := clerk.SessionClaimsFromContext(r.Context())
+
+			if !ok || claims.ActiveOrganizationID == "" || strings.HasPrefix(r.URL.Path, PortalPathPrefix) {
+				record(noTier)
+				return
+			}
+
+			// tiers are cached, so this only queries on the organisation's first request
+			// every few minutes
+			record(tiers.Tier(r.Context(), claims.ActiveOrganizationID))
+		})
+	}
+}
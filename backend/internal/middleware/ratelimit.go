@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/ratelimit"
+	"github.com/davidtaing/property-management/internal/types"
+	"github.com/gorilla/mux"
+)
+
+// routeClasses are the route templates limited as a class of their own, rather than
+// as reads or writes
+var routeClasses = map[string]ratelimit.Class{
+	"/portfolios/{user_id}": ratelimit.Export,
+	"/portal/statement":     ratelimit.Export,
+	"/landlords/batch":      ratelimit.Batch,
+	"/properties/batch":     ratelimit.Batch,
+	"/tenants/batch":        ratelimit.Batch,
+}
+
+// Limits requests per route class, for each user and for their organisation. Portal
+// users aren't members of an organisation so they're only held to their own limit.
+// There are no API keys yet, so every caller is limited as a Clerk user.
+// The remaining quota is returned in the RateLimit-* headers, and requests over the
+// limit get a 429 with a Retry-After header.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, limits map[ratelimit.Class]ratelimit.Limits) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := routeClass(r)
+			classLimits := limits[class]
+
+			org, _ := r.Context().Value(types.OrgIDKey).(string)
+			userID, _ := r.Context().Value(types.UserIDKey).(string)
+
+			keys := []ratelimit.Key{
+				{Name: "user:" + userID + ":" + string(class), Limit: classLimits.User},
+			}
+
+			if org != "" {
+				keys = append(keys, ratelimit.Key{Name: "org:" + org + ":" + string(class), Limit: classLimits.Organisation})
+			}
+
+			result := limiter.Allow(keys...)
+
+			if result.Limit > 0 {
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+				w.Header().Set("RateLimit-Reset", seconds(result.Reset))
+			}
+
+			if !result.Allowed {
+				requestID, _ := r.Context().Value(types.RequestIDKey).(string)
+
+				w.Header().Set("Retry-After", seconds(result.RetryAfter))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(models.Error{
+					Code:      http.StatusTooManyRequests,
+					ErrorCode: models.RateLimited,
+					Message:   "Too many requests, retry after " + seconds(result.RetryAfter) + " seconds",
+					RequestId: &requestID,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func routeClass(r *http.Request) ratelimit.Class {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			if class, ok := routeClasses[template]; ok {
+				return class
+			}
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ratelimit.Read
+	default:
+		return ratelimit.Write
+	}
+}
+
+// seconds rounds up, so a caller that waits as long as they're told isn't refused
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/ratelimit"
+	"github.com/gorilla/mux"
+)
+
+func TestRouteClass(t *testing.T) {
+	var got ratelimit.Class
+
+	router := mux.NewRouter()
+	record := func(w http.ResponseWriter, r *http.Request) {
+		got = routeClass(r)
+	}
+
+	// registered in the same order, and with the same methods, as the generated router
+	routes := []struct {
+		template string
+		methods  []string
+	}{
+		{"/landlords", []string{http.MethodGet, http.MethodPost}},
+		{"/landlords/batch", []string{http.MethodPost}},
+		{"/landlords/{id}", []string{http.MethodGet, http.MethodPatch, http.MethodDelete}},
+		{"/portfolios/{user_id}", []string{http.MethodGet}},
+		{"/properties/batch", []string{http.MethodPost}},
+		{"/tenants/batch", []string{http.MethodPost}},
+	}
+
+	for _, route := range routes {
+		router.HandleFunc(route.template, record).Methods(route.methods...)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		want   ratelimit.Class
+	}{
+		{http.MethodGet, "/landlords", ratelimit.Read},
+		{http.MethodPost, "/landlords", ratelimit.Write},
+		{http.MethodPatch, "/landlords/0197a0f6-0000-7000-8000-000000000000", ratelimit.Write},
+		{http.MethodPost, "/landlords/batch", ratelimit.Batch},
+		{http.MethodPost, "/properties/batch", ratelimit.Batch},
+		{http.MethodPost, "/tenants/batch", ratelimit.Batch},
+		{http.MethodGet, "/portfolios/user_1", ratelimit.Export},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			got = ""
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/davidtaing/property-management/internal/logging"
+	"github.com/davidtaing/property-management/internal/types"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern limits the IDs accepted from callers so they're safe to log
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Uses the caller's X-Request-ID, or generates one, and returns it in the response.
+// A logger tagged with the request ID is added to the request context.
+func RequestIDMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+
+			if !requestIDPattern.MatchString(requestID) {
+				requestID = uuid.NewString()
+			}
+
+			w.Header().Set(RequestIDHeader, requestID)
+
+			ctx := context.WithValue(r.Context(), types.RequestIDKey, requestID)
+			ctx = logging.NewContext(ctx, logger.With("request_id", requestID))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
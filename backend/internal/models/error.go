@@ -0,0 +1,6 @@
+package models
+
+// Error allows API errors to be returned as errors and mapped to a response
+func (e Error) Error() string {
+	return e.Message
+}
@@ -0,0 +1,210 @@
+// Package ratelimit limits how often callers can use the API with token buckets. The
+// buckets are kept in memory, so each instance of the server enforces its own limits.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Class groups routes that share a limit
+type Class string
+
+const (
+	Read  Class = "read"
+	Write Class = "write"
+	// Export covers reports that aggregate across the whole portfolio, which are
+	// far more expensive to serve than a read
+	Export Class = "export"
+	// Batch covers the batch endpoints, which can each make hundreds of writes
+	Batch Class = "batch"
+)
+
+// Limit allows Requests every Period, in bursts of up to Requests. The zero Limit
+// doesn't limit anything.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Limits are the limits for a route class. Each caller is held to their own limit,
+// and to their organisation's, so one organisation can't starve the others.
+type Limits struct {
+	Organisation Limit
+	User         Limit
+}
+
+// ParseLimit parses a limit in the form requests/period, e.g. 300/1m, or off
+func ParseLimit(s string) (Limit, error) {
+	if s == "off" {
+		return Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(s, "/")
+
+	if !ok {
+		return Limit{}, fmt.Errorf("limit must be in the form requests/period, e.g. 300/1m, got %q", s)
+	}
+
+	n, err := strconv.Atoi(requests)
+
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("limit must allow a positive number of requests, got %q", s)
+	}
+
+	d, err := time.ParseDuration(period)
+
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("limit must have a positive period such as 1m, got %q", s)
+	}
+
+	return Limit{Requests: n, Period: d}, nil
+}
+
+func (l Limit) enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// perSecond is the rate tokens are added to the bucket
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Key identifies a bucket and the limit it's held to
+type Key struct {
+	Name  string
+	Limit Limit
+}
+
+// Result reports the state of the most constrained bucket for a request
+type Result struct {
+	Allowed bool
+	// Limit is the size of the bucket
+	Limit int
+	// Remaining is the number of requests that can be made right now
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the request would be allowed, when it isn't
+	RetryAfter time.Duration
+}
+
+// sweepInterval is how often buckets that have refilled are dropped, so callers who
+// have gone away don't hold on to memory
+const sweepInterval = time.Minute
+
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds the tokens earned since the bucket was last updated
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed*b.limit.perSecond())
+	b.updated = now
+}
+
+// until is the time until the bucket holds the given number of tokens
+func (b *bucket) until(tokens float64) time.Duration {
+	if b.tokens >= tokens {
+		return 0
+	}
+
+	return time.Duration((tokens - b.tokens) / b.limit.perSecond() * float64(time.Second))
+}
+
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of every key. The request is only allowed when
+// all of them have a token, and none are taken when it isn't. Keys with the zero
+// Limit are ignored.
+func (l *Limiter) Allow(keys ...Key) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	result := Result{Allowed: true}
+	buckets := make([]*bucket, 0, len(keys))
+
+	for _, key := range keys {
+		if !key.Limit.enabled() {
+			continue
+		}
+
+		b, ok := l.buckets[key.Name]
+
+		// the limit is replaced when it's been reconfigured
+		if !ok || b.limit != key.Limit {
+			b = &bucket{limit: key.Limit, tokens: float64(key.Limit.Requests), updated: now}
+			l.buckets[key.Name] = b
+		}
+
+		b.refill(now)
+		buckets = append(buckets, b)
+
+		if b.tokens < 1 {
+			result.Allowed = false
+			result.RetryAfter = max(result.RetryAfter, b.until(1))
+		}
+	}
+
+	if len(buckets) == 0 {
+		return result
+	}
+
+	result.Remaining = math.MaxInt
+
+	for _, b := range buckets {
+		if result.Allowed {
+			b.tokens--
+		}
+
+		remaining := int(math.Max(0, math.Floor(b.tokens)))
+
+		if remaining < result.Remaining {
+			result.Limit = b.limit.Requests
+			result.Remaining = remaining
+			result.Reset = b.until(float64(b.limit.Requests))
+		}
+	}
+
+	return result
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	l.lastSweep = now
+
+	for name, b := range l.buckets {
+		b.refill(now)
+
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(l.buckets, name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "300/1m", want: Limit{Requests: 300, Period: time.Minute}},
+		{in: "10/30s", want: Limit{Requests: 10, Period: 30 * time.Second}},
+		{in: "off", want: Limit{}},
+		{in: "300", wantErr: true},
+		{in: "0/1m", wantErr: true},
+		{in: "10/0s", wantErr: true},
+		{in: "ten/1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+
+	organisation := Key{Name: "org", Limit: Limit{Requests: 3, Period: 3 * time.Second}}
+	user := Key{Name: "user", Limit: Limit{Requests: 2, Period: 2 * time.Second}}
+	otherUser := Key{Name: "other", Limit: Limit{Requests: 2, Period: 2 * time.Second}}
+
+	for i, remaining := range []int{1, 0} {
+		result := l.Allow(organisation, user)
+
+		if !result.Allowed || result.Remaining != remaining || result.Limit != 2 {
+			t.Fatalf("request %d: expected to be allowed with %d remaining, got %+v", i, remaining, result)
+		}
+	}
+
+	result := l.Allow(organisation, user)
+
+	if result.Allowed || result.RetryAfter != time.Second || result.Reset != 2*time.Second {
+		t.Fatalf("expected the user's limit to be reached, got %+v", result)
+	}
+
+	// a denied request doesn't use up the organisation's last token
+	if result := l.Allow(organisation, otherUser); !result.Allowed || result.Remaining != 0 || result.Limit != 3 {
+		t.Fatalf("expected another user to be allowed up to the organisation's limit, got %+v", result)
+	}
+
+	if result := l.Allow(organisation, otherUser); result.Allowed {
+		t.Fatalf("expected the organisation's limit to be reached, got %+v", result)
+	}
+
+	now = now.Add(time.Second)
+
+	if result := l.Allow(organisation, user); !result.Allowed {
+		t.Fatalf("expected a token to have been added after a second, got %+v", result)
+	}
+
+	if result := l.Allow(Key{Name: "unlimited"}); !result.Allowed {
+		t.Fatalf("expected the zero limit not to limit, got %+v", result)
+	}
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidFields is returned when a sparse fieldset names a field the records
+// don't have
+var ErrInvalidFields = errors.New("invalid fields")
+
+// placeholders are selected in place of the columns left out of a sparse fieldset.
+// They have the same types as the columns so the record's scan function can still
+// scan every column. Every column but id, which is always selected, has one. Dates
+// are typed NULLs, as every date is scanned through pgtype.Date, while text, numbers
+// and timestamps scanned straight into the model get their zero values.
+type placeholders map[string]string
+
+const (
+	textPlaceholder     = "''"
+	nullTextPlaceholder = "NULL::text"
+	nullDatePlaceholder = "NULL::date"
+	timePlaceholder     = "'epoch'::timestamptz"
+	nullTimePlaceholder = "NULL::timestamptz"
+	numberPlaceholder   = "0"
+	recordIDPlaceholder = "'00000000-0000-0000-0000-000000000000'::uuid"
+)
+
+var landlordPlaceholders = placeholders{
+	"name":           textPlaceholder,
+	"email":          textPlaceholder,
+	"mobile":         textPlaceholder,
+	"phone":          nullTextPlaceholder,
+	"address_line_1": textPlaceholder,
+	"address_line_2": nullTextPlaceholder,
+	"suburb":         textPlaceholder,
+	"postcode":       textPlaceholder,
+	"state":          textPlaceholder,
+	"country":        textPlaceholder,
+	"is_archived":    nullTimePlaceholder,
+	"created_at":     timePlaceholder,
+	"updated_at":     timePlaceholder,
+}
+
+var propertyPlaceholders = placeholders{
+	"street_number":     textPlaceholder,
+	"street_name":       textPlaceholder,
+	"suburb":            textPlaceholder,
+	"state":             textPlaceholder,
+	"postcode":          textPlaceholder,
+	"country":           textPlaceholder,
+	"landlord_id":       recordIDPlaceholder,
+	"assigned_to":       nullTextPlaceholder,
+	"management_fee":    numberPlaceholder,
+	"management_gained": nullDatePlaceholder,
+	"management_lost":   nullDatePlaceholder,
+	"is_archived":       nullTimePlaceholder,
+	"created_at":        timePlaceholder,
+	"updated_at":        timePlaceholder,
+}
+
+var tenantPlaceholders = placeholders{
+	"name":                textPlaceholder,
+	"email":               textPlaceholder,
+	"mobile":              textPlaceholder,
+	"phone":               nullTextPlaceholder,
+	"paid_from":           nullDatePlaceholder,
+	"paid_to":             nullDatePlaceholder,
+	"rental_amount":       numberPlaceholder,
+	"frequency":           textPlaceholder,
+	"original_start_date": nullDatePlaceholder,
+	"start_date":          nullDatePlaceholder,
+	"end_date":            nullDatePlaceholder,
+	"termination_date":    nullDatePlaceholder,
+	"termination_reason":  nullTextPlaceholder,
+	"vacate_date":         nullDatePlaceholder,
+	"is_archived":         nullTimePlaceholder,
+	"property_id":         recordIDPlaceholder,
+	"created_at":          timePlaceholder,
+	"updated_at":          timePlaceholder,
+}
+
+// selectColumns returns the select list for a sparse fieldset, with placeholders for
+// the columns that weren't asked for. Every column is selected when no fields are
+// given, and the keyset's columns always are so that cursors can be made from the
+// records.
+func selectColumns(columns string, placeholders placeholders, fields []string, keys keyset) (string, error) {
+	if len(fields) == 0 {
+		return columns, nil
+	}
+
+	selected := map[string]bool{"id": true}
+
+	for _, field := range fields {
+		if _, ok := placeholders[field]; !ok && field != "id" {
+			return "", ErrInvalidFields
+		}
+
+		selected[field] = true
+	}
+
+	for _, key := range keys {
+		selected[key.column] = true
+	}
+
+	terms := []string{}
+
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimSpace(column)
+
+		if selected[column] {
+			terms = append(terms, column)
+			continue
+		}
+
+		terms = append(terms, placeholders[column]+" AS "+column)
+	}
+
+	return strings.Join(terms, ",\n"), nil
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelectColumns(t *testing.T) {
+	columns := `
+		id,
+		name,
+		email,
+		phone,
+		created_at
+	`
+
+	placeholders := placeholders{
+		"name":       textPlaceholder,
+		"email":      textPlaceholder,
+		"phone":      nullTextPlaceholder,
+		"created_at": timePlaceholder,
+	}
+
+	keys := keyset{{column: "created_at", desc: true}, {column: "id"}}
+
+	got, err := selectColumns(columns, placeholders, []string{"name"}, keys)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,\nname,\n'' AS email,\nNULL::text AS phone,\ncreated_at"
+
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if got, _ := selectColumns(columns, placeholders, nil, keys); got != columns {
+		t.Errorf("expected every column without fields, got %q", got)
+	}
+
+	if _, err := selectColumns(columns, placeholders, []string{"password"}, keys); !errors.Is(err, ErrInvalidFields) {
+		t.Errorf("expected ErrInvalidFields for an unknown field, got %v", err)
+	}
+}
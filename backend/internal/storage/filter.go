@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// condition is a filter that builds its own SQL, such as a range or a set of values.
+// where returns the clauses for the column, their parameters and the next parameter
+// number.
+type condition interface {
+	where(column string, paramCount int) ([]string, []interface{}, int)
+}
+
+// Range matches values between From and To, inclusive. Either end can be left open,
+// and the zero Range matches everything.
+type Range[T any] struct {
+	From *T
+	To   *T
+}
+
+func (r Range[T]) where(column string, paramCount int) ([]string, []interface{}, int) {
+	clauses := []string{}
+	params := []interface{}{}
+
+	if r.From != nil {
+		clauses = append(clauses, fmt.Sprintf("%s >= $%d", column, paramCount))
+		params = append(params, *r.From)
+		paramCount++
+	}
+
+	if r.To != nil {
+		clauses = append(clauses, fmt.Sprintf("%s <= $%d", column, paramCount))
+		params = append(params, *r.To)
+		paramCount++
+	}
+
+	return clauses, params, paramCount
+}
+
+// Set matches any of its values exactly. The empty Set matches everything.
+type Set []string
+
+func (s Set) where(column string, paramCount int) ([]string, []interface{}, int) {
+	if len(s) == 0 {
+		return nil, nil, paramCount
+	}
+
+	// compared as text so the same set works for text, UUID and enum columns
+	clause := fmt.Sprintf("%s::text = ANY($%d)", column, paramCount)
+
+	return []string{clause}, []interface{}{[]string(s)}, paramCount + 1
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildWhereClauseConditions(t *testing.T) {
+	from := 1000.0
+
+	tests := []struct {
+		name       string
+		value      any
+		want       string
+		wantParams []any
+	}{
+		{"range", Range[float64]{From: &from}, "WHERE rental_amount >= $1", []any{1000.0}},
+		{"empty range", Range[float64]{}, "", []any{}},
+		{"set", Set{"weekly", "monthly"}, "WHERE rental_amount::text = ANY($1)", []any{[]string{"weekly", "monthly"}}},
+		{"empty set", Set(nil), "", []any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, params, _ := buildWhereClause(map[string]interface{}{"rental_amount": tt.value})
+
+			if got != tt.want || !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("expected %q %v, got %q %v", tt.want, tt.wantParams, got, params)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdempotencyKeyTTL is how long a response is kept for retries with the same key
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// idempotencyKeyLockTimeout is how long a request keeps its key before a retry can
+// take it over, in case the server stopped before the request finished. It's longer
+// than any request is allowed to run. A request that's still running when its key is
+// taken over can't store its response once the retry has, so its writes are rolled
+// back rather than repeated.
+const idempotencyKeyLockTimeout = 2 * time.Minute
+
+// ErrIdempotencyKeyReused is returned when a key is used again for a different request
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused")
+
+// ErrIdempotencyKeyInUse is returned when the first request with a key hasn't finished
+var ErrIdempotencyKeyInUse = errors.New("idempotency key in use")
+
+// IdempotencyRepository keeps the responses to requests made with an Idempotency-Key,
+// so a retried request gets the first response rather than repeating its writes
+type IdempotencyRepository interface {
+	// Claim reserves the key for a request, identified by a hash of it. It returns the
+	// stored response when the request has been made before, ErrIdempotencyKeyReused
+	// when the key was used for a different request, and ErrIdempotencyKeyInUse while
+	// the first request is still running.
+	Claim(ctx context.Context, organisationID string, key string, requestHash string) (*StoredResponse, error)
+	// Complete stores the response to the request that claimed the key. It's run in
+	// the transaction that made the request's writes, so they're committed together.
+	// It returns ErrIdempotencyKeyInUse when the key no longer waits on a response, as
+	// when another request took it over and finished first.
+	Complete(ctx context.Context, organisationID string, key string, response StoredResponse) error
+	// Release gives up a claimed key without storing a response, so it can be retried
+	Release(ctx context.Context, organisationID string, key string) error
+}
+
+// StoredResponse is the response replayed for a retried request
+type StoredResponse struct {
+	Status int
+	Body   []byte
+	ETag   string
+}
+
+type PostgresIdempotencyRepository struct {
+	db querier
+}
+
+func NewPostgresIdempotencyRepository(dbpool *pgxpool.Pool) *PostgresIdempotencyRepository {
+	return &PostgresIdempotencyRepository{db: dbpool}
+}
+
+func (r *PostgresIdempotencyRepository) Claim(ctx context.Context, organisationID string, key string, requestHash string) (*StoredResponse, error) {
+	// expired keys are removed as they're used, rather than by a scheduled job
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE
+			organisation_id = $1
+			AND created_at < NOW() - make_interval(secs => $2)
+	`, organisationID, IdempotencyKeyTTL.Seconds())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// a key that's still held by a request that never finished is taken over
+	var claimed bool
+
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO idempotency_keys (organisation_id, key, request_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organisation_id, key) DO UPDATE
+		SET
+			request_hash = EXCLUDED.request_hash,
+			created_at = NOW()
+		WHERE
+			idempotency_keys.status IS NULL
+			AND idempotency_keys.request_hash = EXCLUDED.request_hash
+			AND idempotency_keys.created_at < NOW() - make_interval(secs => $4)
+		RETURNING true
+	`, organisationID, key, requestHash, idempotencyKeyLockTimeout.Seconds()).Scan(&claimed)
+
+	if err == nil {
+		return nil, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	var storedHash string
+	var status *int
+	var response StoredResponse
+	var etag *string
+
+	err = r.db.QueryRow(ctx, `
+		SELECT request_hash, status, body, etag
+		FROM idempotency_keys
+		WHERE
+			organisation_id = $1
+			AND key = $2
+	`, organisationID, key).Scan(&storedHash, &status, &response.Body, &etag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if storedHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	if status == nil {
+		return nil, ErrIdempotencyKeyInUse
+	}
+
+	response.Status = *status
+
+	if etag != nil {
+		response.ETag = *etag
+	}
+
+	return &response, nil
+}
+
+func (r *PostgresIdempotencyRepository) Complete(ctx context.Context, organisationID string, key string, response StoredResponse) error {
+	// the row stays locked until the transaction ends, so a retry that takes the key
+	// over waits for it, and then finds the response
+	result, err := r.db.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET
+			status = $3,
+			body = $4,
+			etag = NULLIF($5, '')
+		WHERE
+			organisation_id = $1
+			AND key = $2
+			AND status IS NULL
+	`, organisationID, key, response.Status, response.Body, response.ETag)
+
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrIdempotencyKeyInUse
+	}
+
+	return nil
+}
+
+func (r *PostgresIdempotencyRepository) Release(ctx context.Context, organisationID string, key string) error {
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE
+			organisation_id = $1
+			AND key = $2
+			AND status IS NULL
+	`, organisationID, key)
+
+	return err
+}
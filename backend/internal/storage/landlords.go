@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LandlordRepository reads and writes landlords within an organisation
+type LandlordRepository interface {
+	List(ctx context.Context, organisationID string, filter LandlordFilter) ([]models.Landlord, PageInfo, error)
+	// ListVersion returns the version of the records List would return for the filter,
+	// ignoring its page, sort and fields
+	ListVersion(ctx context.Context, organisationID string, filter LandlordFilter) (ListVersion, error)
+	Get(ctx context.Context, organisationID string, id string) (models.Landlord, error)
+	// GetMany returns the landlords with the given IDs, leaving out any that don't exist
+	GetMany(ctx context.Context, organisationID string, ids []string) ([]models.Landlord, error)
+	Create(ctx context.Context, organisationID string, payload models.CreateLandlord) (models.Landlord, error)
+	// Update and Archive fail with ErrPreconditionFailed when the landlord isn't at one of
+	// the given versions
+	Update(ctx context.Context, organisationID string, id string, payload models.UpdateLandlord, versions Versions) (models.Landlord, error)
+	Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Landlord, error)
+}
+
+// LandlordFilter narrows and pages the landlords returned by List
+type LandlordFilter struct {
+	Name         *string
+	ArchivedOnly *bool
+	Suburb       Set
+	State        Set
+	// Sort is the fields to sort by, e.g. -created_at for the newest first
+	Sort []string
+	// Fields limits the columns selected to these and id, all of them when empty
+	Fields []string
+	Page
+}
+
+// landlordColumns are selected in the order scanLandlord expects
+const landlordColumns = `
+	id,
+	name,
+	email,
+	mobile,
+	phone,
+	address_line_1,
+	address_line_2,
+	suburb,
+	postcode,
+	state,
+	country,
+	is_archived,
+	created_at,
+	updated_at
+`
+
+// landlordKeys is the order lists of landlords are returned in when no sort is given
+var landlordKeys = keyset{{column: "name"}, {column: "id"}}
+
+type PostgresLandlordRepository struct {
+	db querier
+}
+
+func NewPostgresLandlordRepository(dbpool *pgxpool.Pool) *PostgresLandlordRepository {
+	return &PostgresLandlordRepository{db: dbpool}
+}
+
+func (r *PostgresLandlordRepository) List(ctx context.Context, organisationID string, filter LandlordFilter) ([]models.Landlord, PageInfo, error) {
+	keys, err := sortKeys(filter.Sort, landlordSortFields, landlordKeys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	columns, err := selectColumns(landlordColumns, landlordPlaceholders, filter.Fields, keys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	whereClause, queryParams, paramCount := buildWhereClause(landlordConditions(organisationID, filter))
+
+	return listPage(ctx, r.db, listQuery{
+		table:      "landlords",
+		columns:    columns,
+		where:      whereClause,
+		params:     queryParams,
+		paramCount: paramCount,
+		keys:       keys,
+	}, filter.Page, scanLandlord)
+}
+
+func (r *PostgresLandlordRepository) ListVersion(ctx context.Context, organisationID string, filter LandlordFilter) (ListVersion, error) {
+	whereClause, queryParams, paramCount := buildWhereClause(landlordConditions(organisationID, filter))
+
+	return listVersion(ctx, r.db, "landlords", organisationID, whereClause, queryParams, paramCount)
+}
+
+// landlordConditions are the columns List matches the filter against
+func landlordConditions(organisationID string, filter LandlordFilter) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            filter.Name,
+		"archived_only":   filter.ArchivedOnly,
+		"suburb":          filter.Suburb,
+		"state":           filter.State,
+		"organisation_id": organisationID,
+	}
+}
+
+func (r *PostgresLandlordRepository) Get(ctx context.Context, organisationID string, id string) (models.Landlord, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM landlords
+		WHERE
+			id = $1
+			AND organisation_id = $2
+	`, landlordColumns)
+
+	row := r.db.QueryRow(ctx, sql, id, organisationID)
+
+	landlord, err := scanLandlord(row)
+
+	return landlord, notFound(err)
+}
+
+func (r *PostgresLandlordRepository) GetMany(ctx context.Context, organisationID string, ids []string) ([]models.Landlord, error) {
+	return getMany(ctx, r.db, "landlords", landlordColumns, organisationID, ids, scanLandlord)
+}
+
+func (r *PostgresLandlordRepository) Create(ctx context.Context, organisationID string, payload models.CreateLandlord) (models.Landlord, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.Landlord{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO landlords (
+			id,
+			name,
+			email,
+			mobile,
+			phone,
+			address_line_1,
+			address_line_2,
+			suburb,
+			postcode,
+			state,
+			country,
+			organisation_id
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12
+		) RETURNING %s
+	`, landlordColumns)
+
+	row := r.db.QueryRow(
+		ctx,
+		sql,
+		id.String(),
+		payload.Name,
+		payload.Email,
+		payload.Mobile,
+		payload.Phone,
+		payload.AddressLine1,
+		payload.AddressLine2,
+		payload.Suburb,
+		payload.Postcode,
+		payload.State,
+		payload.Country,
+		organisationID,
+	)
+
+	return scanLandlord(row)
+}
+
+func (r *PostgresLandlordRepository) Update(ctx context.Context, organisationID string, id string, payload models.UpdateLandlord, versions Versions) (models.Landlord, error) {
+	setClause, values, paramCount := buildLandlordUpdateSetClause(payload)
+	values = append(values, id, organisationID)
+
+	versionClause, versionParams := versions.and(paramCount + 3)
+	values = append(values, versionParams...)
+
+	sql := fmt.Sprintf(`
+		UPDATE landlords
+		%s
+		WHERE
+			id = $%d
+			AND organisation_id = $%d
+			%s
+		RETURNING %s
+	`, setClause, paramCount+1, paramCount+2, versionClause, landlordColumns)
+
+	row := r.db.QueryRow(ctx, sql, values...)
+
+	landlord, err := scanLandlord(row)
+
+	return landlord, conditionalWriteError(ctx, r.db, "landlords", organisationID, id, versions, err)
+}
+
+func (r *PostgresLandlordRepository) Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Landlord, error) {
+	versionClause, versionParams := versions.and(3)
+
+	sql := fmt.Sprintf(`
+		UPDATE landlords
+		SET
+			is_archived = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = $1
+			AND organisation_id = $2
+			%s
+		RETURNING %s
+	`, versionClause, landlordColumns)
+
+	row := r.db.QueryRow(ctx, sql, append([]interface{}{id, organisationID}, versionParams...)...)
+
+	landlord, err := scanLandlord(row)
+
+	return landlord, conditionalWriteError(ctx, r.db, "landlords", organisationID, id, versions, err)
+}
+
+func scanLandlord(scanner scanner) (models.Landlord, error) {
+	var landlord models.Landlord
+
+	err := scanner.Scan(
+		&landlord.Id,
+		&landlord.Name,
+		&landlord.Email,
+		&landlord.Mobile,
+		&landlord.Phone,
+		&landlord.AddressLine1,
+		&landlord.AddressLine2,
+		&landlord.Suburb,
+		&landlord.Postcode,
+		&landlord.State,
+		&landlord.Country,
+		&landlord.IsArchived,
+		&landlord.CreatedAt,
+		&landlord.UpdatedAt,
+	)
+
+	return landlord, err
+}
+
+func buildLandlordUpdateSetClause(payload models.UpdateLandlord) (string, []interface{}, int) {
+	fields := []string{}
+	values := []interface{}{}
+	paramCount := 0
+
+	if payload.Name != nil && *payload.Name != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("name = $%d", paramCount))
+		values = append(values, *payload.Name)
+	}
+
+	if payload.Email != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("email = $%d", paramCount))
+		values = append(values, *payload.Email)
+	}
+
+	if payload.Mobile != nil && *payload.Mobile != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("mobile = $%d", paramCount))
+		values = append(values, *payload.Mobile)
+	}
+
+	if payload.Phone.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("phone = $%d", paramCount))
+		values = append(values, nullableValue(payload.Phone))
+	}
+
+	if payload.AddressLine1 != nil && *payload.AddressLine1 != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("address_line_1 = $%d", paramCount))
+		values = append(values, *payload.AddressLine1)
+	}
+
+	if payload.AddressLine2.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("address_line_2 = $%d", paramCount))
+		values = append(values, nullableValue(payload.AddressLine2))
+	}
+
+	if payload.Suburb != nil && *payload.Suburb != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("suburb = $%d", paramCount))
+		values = append(values, *payload.Suburb)
+	}
+
+	if payload.Postcode != nil && *payload.Postcode != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("postcode = $%d", paramCount))
+		values = append(values, *payload.Postcode)
+	}
+
+	if payload.State != nil && *payload.State != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("state = $%d", paramCount))
+		values = append(values, *payload.State)
+	}
+
+	if payload.Country != nil && *payload.Country != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("country = $%d", paramCount))
+		values = append(values, *payload.Country)
+	}
+
+	if payload.IsArchived.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("is_archived = $%d", paramCount))
+		values = append(values, nullableValue(payload.IsArchived))
+	}
+
+	fields = append(fields, "updated_at = NOW()")
+
+	setClause := "SET\n" + strings.Join(fields, ",\n")
+
+	return setClause, values, paramCount
+}
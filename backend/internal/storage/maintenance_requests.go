@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// MaintenanceRequestRepository reads and writes maintenance requests within an
+// organisation
+type MaintenanceRequestRepository interface {
+	// List returns the organisation's requests, sorted by the fields in sort
+	List(ctx context.Context, organisationID string, status *models.MaintenanceStatus, sort []string) ([]models.MaintenanceRequest, error)
+	Update(ctx context.Context, organisationID string, id string, payload models.UpdateMaintenanceRequest) (models.MaintenanceRequest, error)
+
+	// ListForPortalUser returns the requests on a landlord's properties, or the
+	// requests a tenant has lodged
+	ListForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.MaintenanceRequest, error)
+
+	// CreateForTenant raises a request against the property the tenant is renting. It
+	// fails with ErrTenancyEnded when the tenancy has been archived or vacated.
+	CreateForTenant(ctx context.Context, organisationID string, tenantID openapi_types.UUID, payload models.CreateMaintenanceRequest) (models.MaintenanceRequest, error)
+}
+
+// ErrTenancyEnded is returned when a tenant whose tenancy has been archived or vacated
+// lodges a maintenance request
+var ErrTenancyEnded = errors.New("tenancy ended")
+
+// maintenanceRequestColumns are selected in the order scanMaintenanceRequest expects
+const maintenanceRequestColumns = `
+	id,
+	property_id,
+	tenant_id,
+	title,
+	description,
+	status,
+	created_at,
+	updated_at
+`
+
+// maintenanceRequestKeys is the order requests are listed in when no sort is given
+var maintenanceRequestKeys = keyset{{column: "created_at", desc: true}, {column: "id"}}
+
+type PostgresMaintenanceRequestRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresMaintenanceRequestRepository(dbpool *pgxpool.Pool) *PostgresMaintenanceRequestRepository {
+	return &PostgresMaintenanceRequestRepository{dbpool: dbpool}
+}
+
+func (r *PostgresMaintenanceRequestRepository) List(ctx context.Context, organisationID string, status *models.MaintenanceStatus, sort []string) ([]models.MaintenanceRequest, error) {
+	keys, err := sortKeys(sort, maintenanceRequestSortFields, maintenanceRequestKeys)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM maintenance_requests
+		WHERE
+			organisation_id = $1
+			AND ($2::maintenance_status IS NULL OR status = $2)
+		%s
+	`, maintenanceRequestColumns, keys.orderBy(false))
+
+	return r.list(ctx, sql, organisationID, status)
+}
+
+func (r *PostgresMaintenanceRequestRepository) Update(ctx context.Context, organisationID string, id string, payload models.UpdateMaintenanceRequest) (models.MaintenanceRequest, error) {
+	sql := fmt.Sprintf(`
+		UPDATE maintenance_requests
+		SET
+			status = COALESCE($1, status),
+			updated_at = NOW()
+		WHERE
+			id = $2
+			AND organisation_id = $3
+		RETURNING %s
+	`, maintenanceRequestColumns)
+
+	row := r.dbpool.QueryRow(
+		ctx,
+		sql,
+		payload.Status,
+		id,
+		organisationID,
+	)
+
+	maintenanceRequest, err := scanMaintenanceRequest(row)
+
+	return maintenanceRequest, notFound(err)
+}
+
+func (r *PostgresMaintenanceRequestRepository) ListForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.MaintenanceRequest, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM maintenance_requests
+		WHERE
+			organisation_id = $1
+			AND (
+				property_id IN (SELECT id FROM properties WHERE landlord_id = $2)
+				OR tenant_id = $3
+			)
+		ORDER BY created_at DESC
+	`, maintenanceRequestColumns)
+
+	return r.list(ctx, sql, organisationID, portalUser.LandlordId, portalUser.TenantId)
+}
+
+func (r *PostgresMaintenanceRequestRepository) CreateForTenant(ctx context.Context, organisationID string, tenantID openapi_types.UUID, payload models.CreateMaintenanceRequest) (models.MaintenanceRequest, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.MaintenanceRequest{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO maintenance_requests (
+			id,
+			organisation_id,
+			property_id,
+			tenant_id,
+			title,
+			description
+		)
+		SELECT
+			$1,
+			organisation_id,
+			property_id,
+			id,
+			$2,
+			$3
+		FROM tenants
+		WHERE
+			id = $4
+			AND organisation_id = $5
+			AND %s
+		RETURNING %s
+	`, activeTenancy(5), maintenanceRequestColumns)
+
+	row := r.dbpool.QueryRow(
+		ctx,
+		sql,
+		id.String(),
+		payload.Title,
+		payload.Description,
+		tenantID,
+		organisationID,
+	)
+
+	maintenanceRequest, err := scanMaintenanceRequest(row)
+
+	// the portal user's tenant always exists, so it's no longer renting
+	if errors.Is(err, pgx.ErrNoRows) {
+		return maintenanceRequest, ErrTenancyEnded
+	}
+
+	return maintenanceRequest, err
+}
+
+func (r *PostgresMaintenanceRequestRepository) list(ctx context.Context, sql string, args ...interface{}) ([]models.MaintenanceRequest, error) {
+	maintenanceRequests := []models.MaintenanceRequest{}
+
+	rows, err := r.dbpool.Query(ctx, sql, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		maintenanceRequest, err := scanMaintenanceRequest(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		maintenanceRequests = append(maintenanceRequests, maintenanceRequest)
+	}
+
+	return maintenanceRequests, rows.Err()
+}
+
+func scanMaintenanceRequest(scanner scanner) (models.MaintenanceRequest, error) {
+	var maintenanceRequest models.MaintenanceRequest
+
+	err := scanner.Scan(
+		&maintenanceRequest.Id,
+		&maintenanceRequest.PropertyId,
+		&maintenanceRequest.TenantId,
+		&maintenanceRequest.Title,
+		&maintenanceRequest.Description,
+		&maintenanceRequest.Status,
+		&maintenanceRequest.CreatedAt,
+		&maintenanceRequest.UpdatedAt,
+	)
+
+	return maintenanceRequest, err
+}
@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrganisationRepository reads and writes organisation settings. Organisations are
+// managed in Clerk, so the settings row is created the first time it's written. Until
+// then the organisation has the default settings.
+type OrganisationRepository interface {
+	Get(ctx context.Context, organisationID string) (models.Organisation, error)
+	Update(ctx context.Context, organisationID string, payload models.UpdateOrganisation) (models.Organisation, error)
+	// Tier returns the organisation's tier, without reading the rest of its settings
+	Tier(ctx context.Context, organisationID string) (string, error)
+}
+
+// The defaults of the settings columns, for organisations without a settings row
+const (
+	DefaultTier     = "standard"
+	DefaultTimezone = "Australia/Sydney"
+	DefaultCurrency = "AUD"
+)
+
+// organisationColumns are selected in the order scanOrganisation expects
+const organisationColumns = `
+	id,
+	trading_name,
+	licence_number,
+	abn,
+	timezone,
+	currency,
+	default_management_fee,
+	default_inspection_interval,
+	logo_url,
+	primary_colour,
+	tier,
+	created_at,
+	updated_at
+`
+
+type PostgresOrganisationRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresOrganisationRepository(dbpool *pgxpool.Pool) *PostgresOrganisationRepository {
+	return &PostgresOrganisationRepository{dbpool: dbpool}
+}
+
+func (r *PostgresOrganisationRepository) Get(ctx context.Context, organisationID string) (models.Organisation, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM organisations
+		WHERE id = $1
+	`, organisationColumns)
+
+	row := r.dbpool.QueryRow(ctx, sql, organisationID)
+
+	organisation, err := scanOrganisation(row)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return defaultOrganisation(organisationID), nil
+	}
+
+	return organisation, err
+}
+
+func (r *PostgresOrganisationRepository) Update(ctx context.Context, organisationID string, payload models.UpdateOrganisation) (models.Organisation, error) {
+	err := r.ensureOrganisation(ctx, organisationID)
+
+	if err != nil {
+		return models.Organisation{}, err
+	}
+
+	setClause, values, paramCount := buildOrganisationUpdateSetClause(payload)
+	values = append(values, organisationID)
+
+	sql := fmt.Sprintf(`
+		UPDATE organisations
+		%s
+		WHERE id = $%d
+		RETURNING %s
+	`, setClause, paramCount+1, organisationColumns)
+
+	row := r.dbpool.QueryRow(ctx, sql, values...)
+
+	organisation, err := scanOrganisation(row)
+
+	return organisation, notFound(err)
+}
+
+func (r *PostgresOrganisationRepository) Tier(ctx context.Context, organisationID string) (string, error) {
+	var tier string
+
+	err := r.dbpool.QueryRow(ctx, `
+		SELECT tier
+		FROM organisations
+		WHERE id = $1
+	`, organisationID).Scan(&tier)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultTier, nil
+	}
+
+	return tier, err
+}
+
+// defaultOrganisation returns the settings of an organisation that hasn't changed
+// them. It hasn't been written, so it's as of now.
+func defaultOrganisation(organisationID string) models.Organisation {
+	now := time.Now().UTC()
+	tier := DefaultTier
+
+	return models.Organisation{
+		Id:        &organisationID,
+		Timezone:  DefaultTimezone,
+		Currency:  DefaultCurrency,
+		Tier:      &tier,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ensureOrganisation creates the settings row for an organisation with the
+// default settings if it doesn't exist yet
+func (r *PostgresOrganisationRepository) ensureOrganisation(ctx context.Context, organisationID string) error {
+	_, err := r.dbpool.Exec(ctx, `
+		INSERT INTO organisations (id)
+		VALUES ($1)
+		ON CONFLICT (id) DO NOTHING
+	`, organisationID)
+
+	return err
+}
+
+func scanOrganisation(scanner scanner) (models.Organisation, error) {
+	var organisation models.Organisation
+
+	err := scanner.Scan(
+		&organisation.Id,
+		&organisation.TradingName,
+		&organisation.LicenceNumber,
+		&organisation.Abn,
+		&organisation.Timezone,
+		&organisation.Currency,
+		&organisation.DefaultManagementFee,
+		&organisation.DefaultInspectionInterval,
+		&organisation.Branding.LogoUrl,
+		&organisation.Branding.PrimaryColour,
+		&organisation.Tier,
+		&organisation.CreatedAt,
+		&organisation.UpdatedAt,
+	)
+
+	return organisation, err
+}
+
+func buildOrganisationUpdateSetClause(payload models.UpdateOrganisation) (string, []interface{}, int) {
+	fields := []string{}
+	values := []interface{}{}
+	paramCount := 0
+
+	if payload.TradingName.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("trading_name = $%d", paramCount))
+		values = append(values, nullableValue(payload.TradingName))
+	}
+
+	if payload.LicenceNumber.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("licence_number = $%d", paramCount))
+		values = append(values, nullableValue(payload.LicenceNumber))
+	}
+
+	if payload.Abn.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("abn = REPLACE($%d, ' ', '')", paramCount))
+		values = append(values, nullableValue(payload.Abn))
+	}
+
+	if payload.Timezone != nil && *payload.Timezone != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("timezone = $%d", paramCount))
+		values = append(values, *payload.Timezone)
+	}
+
+	if payload.Currency != nil && *payload.Currency != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("currency = $%d", paramCount))
+		values = append(values, *payload.Currency)
+	}
+
+	if payload.DefaultManagementFee.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("default_management_fee = $%d", paramCount))
+		values = append(values, nullableValue(payload.DefaultManagementFee))
+	}
+
+	if payload.DefaultInspectionInterval.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("default_inspection_interval = $%d", paramCount))
+		values = append(values, nullableValue(payload.DefaultInspectionInterval))
+	}
+
+	if payload.Branding != nil && payload.Branding.LogoUrl.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("logo_url = $%d", paramCount))
+		values = append(values, nullableValue(payload.Branding.LogoUrl))
+	}
+
+	if payload.Branding != nil && payload.Branding.PrimaryColour.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("primary_colour = $%d", paramCount))
+		values = append(values, nullableValue(payload.Branding.PrimaryColour))
+	}
+
+	fields = append(fields, "updated_at = NOW()")
+
+	setClause := "SET\n" + strings.Join(fields, ",\n")
+
+	return setClause, values, paramCount
+}
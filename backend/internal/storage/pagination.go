@@ -0,0 +1,400 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor can't be decoded, or was issued for a
+// list in a different order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page selects a page of a list, either by offset or relative to a cursor
+type Page struct {
+	Limit  int
+	Offset int
+	// After and Before are cursors from a previous page. At most one is set, and when
+	// either is the offset is ignored and the total isn't counted.
+	After  *string
+	Before *string
+}
+
+// PageInfo describes where a page sits in the list
+type PageInfo struct {
+	// Total is the number of records matching the filter, nil when paging by cursor
+	Total *int
+	// Next and Previous are cursors for the adjacent pages, empty at either end
+	Next     string
+	Previous string
+}
+
+// sortKey is a column a list is ordered by. Records where a nullable column is null
+// sort after the rest in either direction.
+type sortKey struct {
+	column   string
+	desc     bool
+	nullable bool
+}
+
+// keyset is the order of a list. The last key must be unique, e.g. id, so that every
+// record has a distinct position to page from.
+type keyset []sortKey
+
+// orderBy returns the ORDER BY clause, reversed when paging backwards
+func (k keyset) orderBy(reverse bool) string {
+	terms := make([]string, len(k))
+
+	for i, key := range k {
+		direction := "ASC"
+
+		if key.desc != reverse {
+			direction = "DESC"
+		}
+
+		terms[i] = key.column + " " + direction
+
+		if key.nullable {
+			if reverse {
+				terms[i] += " NULLS FIRST"
+			} else {
+				terms[i] += " NULLS LAST"
+			}
+		}
+	}
+
+	return "ORDER BY " + strings.Join(terms, ", ")
+}
+
+// predicate matches the records after the cursor's values in the keyset order, or
+// before them when reverse is set. Keys can be sorted in different directions, so the
+// comparison is expanded rather than using a row comparison, e.g. for (a, b):
+//
+//	a > $1 OR (a = $1 AND b > $2)
+//
+// Nulls sort last, so after a value also matches nulls, nothing comes after a null,
+// and before a null matches every value.
+func (k keyset) predicate(values []any, reverse bool, paramCount int) (string, []any, int) {
+	clauses := []string{}
+	params := []any{}
+	// equal matches the cursor's values for the keys before the current one
+	equal := []string{}
+
+	for i, key := range k {
+		op := ">"
+
+		if key.desc != reverse {
+			op = "<"
+		}
+
+		if values[i] == nil {
+			if reverse {
+				clauses = append(clauses, "("+strings.Join(append(slices.Clone(equal), key.column+" IS NOT NULL"), " AND ")+")")
+			}
+
+			equal = append(equal, key.column+" IS NULL")
+			continue
+		}
+
+		param := fmt.Sprintf("$%d", paramCount)
+		params = append(params, values[i])
+		paramCount++
+
+		term := fmt.Sprintf("%s %s %s", key.column, op, param)
+
+		if key.nullable && !reverse {
+			term = fmt.Sprintf("(%s OR %s IS NULL)", term, key.column)
+		}
+
+		clauses = append(clauses, "("+strings.Join(append(slices.Clone(equal), term), " AND ")+")")
+		equal = append(equal, fmt.Sprintf("%s = %s", key.column, param))
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", params, paramCount
+}
+
+// signature identifies the order a cursor was issued for
+func (k keyset) signature() string {
+	terms := make([]string, len(k))
+
+	for i, key := range k {
+		terms[i] = key.column
+
+		if key.desc {
+			terms[i] += " desc"
+		}
+	}
+
+	return strings.Join(terms, ",")
+}
+
+type cursor struct {
+	Keys   string `json:"k"`
+	Values []any  `json:"v"`
+}
+
+func (k keyset) encodeCursor(values []any) string {
+	b, _ := json.Marshal(cursor{Keys: k.signature(), Values: values})
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// cursorFor returns the cursor for a record's position in the list. The values are
+// read from the record's JSON, as the sort fields are named the same as its fields,
+// except for the nullable keys that nulls says are null, as the models don't
+// distinguish null from the zero value.
+func (k keyset) cursorFor(record any, nulls []bool) (string, error) {
+	b, err := json.Marshal(record)
+
+	if err != nil {
+		return "", err
+	}
+
+	var fields map[string]any
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", err
+	}
+
+	values := make([]any, len(k))
+
+	for i, key := range k {
+		if nulls[i] {
+			continue
+		}
+
+		value, ok := fields[key.column]
+
+		if !ok {
+			return "", fmt.Errorf("sort field %s isn't in the record", key.column)
+		}
+
+		values[i] = value
+	}
+
+	return k.encodeCursor(values), nil
+}
+
+func (k keyset) decodeCursor(s string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+
+	if err := json.Unmarshal(b, &c); err != nil || c.Keys != k.signature() || len(c.Values) != len(k) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, key := range k {
+		if c.Values[i] == nil && !key.nullable {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return c.Values, nil
+}
+
+// listQuery is a filtered list of records, paged by listPage
+type listQuery struct {
+	table   string
+	columns string
+	// where is the WHERE clause built by buildWhereClause, params its parameters and
+	// paramCount the next parameter number
+	where      string
+	params     []any
+	paramCount int
+	keys       keyset
+}
+
+// listPage returns a page of the records matched by the query. When paging by
+// offset the total is counted, and when paging by cursor the records are found
+// with a keyset predicate so the page doesn't shift as records are added or removed.
+func listPage[T any](
+	ctx context.Context,
+	db querier,
+	q listQuery,
+	page Page,
+	scan func(scanner) (T, error),
+) ([]T, PageInfo, error) {
+	items := []T{}
+	info := PageInfo{}
+
+	where, params, paramCount := q.where, q.params, q.paramCount
+
+	token, reverse := page.After, false
+
+	if page.Before != nil {
+		token, reverse = page.Before, true
+	}
+
+	if token == nil {
+		sql := fmt.Sprintf(`
+			SELECT COUNT(*)
+			FROM %s
+			%s
+		`, q.table, where)
+
+		var total int
+
+		if err := db.QueryRow(ctx, sql, params...).Scan(&total); err != nil {
+			return nil, info, err
+		}
+
+		info.Total = &total
+	} else {
+		values, err := q.keys.decodeCursor(*token)
+
+		if err != nil {
+			return nil, info, err
+		}
+
+		predicate, predicateParams, next := q.keys.predicate(values, reverse, paramCount)
+		where = andWhere(where, predicate)
+		params = append(params, predicateParams...)
+		paramCount = next
+	}
+
+	// the extra record tells us whether there's another page
+	params = append(params, page.Limit+1)
+	limitClause := fmt.Sprintf("LIMIT $%d", paramCount)
+
+	if token == nil {
+		params = append(params, page.Offset)
+		limitClause += fmt.Sprintf("\nOFFSET $%d", paramCount+1)
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s%s
+		FROM %s
+		%s
+		%s
+		%s
+	`, q.columns, q.keys.nullColumns(), q.table, where, q.keys.orderBy(reverse), limitClause)
+
+	rows, err := db.Query(ctx, sql, params...)
+
+	if err != nil {
+		return nil, info, err
+	}
+	defer rows.Close()
+
+	// nulls records which of each record's nullable keys are null, for its cursor
+	nulls := [][]bool{}
+
+	for rows.Next() {
+		row := &nullsScanner{scanner: rows, keys: q.keys, nulls: make([]bool, len(q.keys))}
+		item, err := scan(row)
+
+		if err != nil {
+			return nil, info, err
+		}
+
+		items = append(items, item)
+		nulls = append(nulls, row.nulls)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, info, err
+	}
+
+	more := len(items) > page.Limit
+
+	if more {
+		items = items[:page.Limit]
+		nulls = nulls[:page.Limit]
+	}
+
+	if reverse {
+		slices.Reverse(items)
+		slices.Reverse(nulls)
+	}
+
+	if len(items) == 0 {
+		return items, info, nil
+	}
+
+	first, err := q.keys.cursorFor(items[0], nulls[0])
+
+	if err != nil {
+		return nil, info, err
+	}
+
+	last, err := q.keys.cursorFor(items[len(items)-1], nulls[len(nulls)-1])
+
+	if err != nil {
+		return nil, info, err
+	}
+
+	switch {
+	case token == nil:
+		if more {
+			info.Next = last
+		}
+
+		if page.Offset > 0 {
+			info.Previous = first
+		}
+	case reverse:
+		// the page was found from a cursor after it, so there's always a next page
+		info.Next = last
+
+		if more {
+			info.Previous = first
+		}
+	default:
+		info.Previous = first
+
+		if more {
+			info.Next = last
+		}
+	}
+
+	return items, info, nil
+}
+
+// nullColumns returns the columns selected after a record's to say whether its
+// nullable keys are null
+func (k keyset) nullColumns() string {
+	columns := ""
+
+	for _, key := range k {
+		if key.nullable {
+			columns += fmt.Sprintf(",\n%s IS NULL", key.column)
+		}
+	}
+
+	return columns
+}
+
+// nullsScanner scans the columns selected by nullColumns after the record's own
+type nullsScanner struct {
+	scanner
+	keys  keyset
+	nulls []bool
+}
+
+func (s *nullsScanner) Scan(dest ...interface{}) error {
+	for i, key := range s.keys {
+		if key.nullable {
+			dest = append(dest, &s.nulls[i])
+		}
+	}
+
+	return s.scanner.Scan(dest...)
+}
+
+// andWhere adds a condition to a WHERE clause built by buildWhereClause
+func andWhere(where string, condition string) string {
+	if where == "" {
+		return "WHERE " + condition
+	}
+
+	return where + "\nAND " + condition
+}
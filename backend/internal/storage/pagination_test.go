@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestKeysetPredicate(t *testing.T) {
+	keys := keyset{{column: "name"}, {column: "paid_to", desc: true}, {column: "id"}}
+
+	tests := []struct {
+		name    string
+		reverse bool
+		want    string
+	}{
+		{
+			name: "after",
+			want: "((name > $3) OR (name = $3 AND paid_to < $4) OR (name = $3 AND paid_to = $4 AND id > $5))",
+		},
+		{
+			name:    "before",
+			reverse: true,
+			want:    "((name < $3) OR (name = $3 AND paid_to > $4) OR (name = $3 AND paid_to = $4 AND id < $5))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, params, next := keys.predicate([]any{"Jane", "2025-07-01", "abc"}, tt.reverse, 3)
+
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+
+			if next != 6 || len(params) != 3 {
+				t.Errorf("expected 3 params and the next to be $6, got %v and $%d", params, next)
+			}
+		})
+	}
+
+	if got, want := keys.orderBy(true), "ORDER BY name DESC, paid_to ASC, id DESC"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestKeysetPredicateNulls(t *testing.T) {
+	keys := keyset{{column: "paid_to", nullable: true}, {column: "id"}}
+
+	tests := []struct {
+		name       string
+		values     []any
+		reverse    bool
+		want       string
+		wantParams int
+	}{
+		{
+			name:       "after a value",
+			values:     []any{"2025-07-01", "abc"},
+			want:       "(((paid_to > $1 OR paid_to IS NULL)) OR (paid_to = $1 AND id > $2))",
+			wantParams: 2,
+		},
+		{
+			name:       "after a null",
+			values:     []any{nil, "abc"},
+			want:       "((paid_to IS NULL AND id > $1))",
+			wantParams: 1,
+		},
+		{
+			name:       "before a value",
+			values:     []any{"2025-07-01", "abc"},
+			reverse:    true,
+			want:       "((paid_to < $1) OR (paid_to = $1 AND id < $2))",
+			wantParams: 2,
+		},
+		{
+			name:       "before a null",
+			values:     []any{nil, "abc"},
+			reverse:    true,
+			want:       "((paid_to IS NOT NULL) OR (paid_to IS NULL AND id < $1))",
+			wantParams: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, params, next := keys.predicate(tt.values, tt.reverse, 1)
+
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+
+			if len(params) != tt.wantParams || next != tt.wantParams+1 {
+				t.Errorf("expected %d params, got %v and the next to be $%d", tt.wantParams, params, next)
+			}
+		})
+	}
+
+	if got, want := keys.orderBy(false), "ORDER BY paid_to ASC NULLS LAST, id ASC"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if got, want := keys.orderBy(true), "ORDER BY paid_to DESC NULLS FIRST, id DESC"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if _, err := keys.decodeCursor(keys.encodeCursor([]any{"2025-07-01", nil})); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected a null id to be rejected, got %v", err)
+	}
+
+	cursor, err := keys.cursorFor(map[string]any{"paid_to": "0001-01-01", "id": "abc"}, []bool{true, false})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values, err := keys.decodeCursor(cursor); err != nil || values[0] != nil {
+		t.Errorf("expected the null paid_to to be kept in the cursor, got %v, %v", values, err)
+	}
+}
+
+func TestKeysetCursor(t *testing.T) {
+	keys := keyset{{column: "name"}, {column: "id"}}
+	values := []any{"Jane", "0197a0f6-0000-7000-8000-000000000000"}
+
+	got, err := keys.decodeCursor(keys.encodeCursor(values))
+
+	if err != nil || !reflect.DeepEqual(got, values) {
+		t.Fatalf("expected the cursor to round trip, got %v, %v", got, err)
+	}
+
+	other := keyset{{column: "name", desc: true}, {column: "id"}}
+
+	for _, cursor := range []string{"not-a-cursor", other.encodeCursor(values)} {
+		if _, err := keys.decodeCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("expected %q to be rejected, got %v", cursor, err)
+		}
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	defaults := keyset{{column: "name"}, {column: "id"}}
+	sortable := []string{"name", "paid_to"}
+
+	tests := []struct {
+		name    string
+		fields  []string
+		want    keyset
+		wantErr bool
+	}{
+		{name: "default", want: defaults},
+		{name: "ascending and descending", fields: []string{"-paid_to", "name"}, want: keyset{{column: "paid_to", desc: true, nullable: true}, {column: "name"}, {column: "id"}}},
+		{name: "not sortable", fields: []string{"mobile"}, wantErr: true},
+		{name: "repeated", fields: []string{"name", "-name"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sortKeys(tt.fields, sortable, defaults)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Fatalf("expected ErrInvalidSort, got %v", err)
+				}
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v, %v", tt.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// PaymentRepository records the rent tenants pay within an organisation, and reports
+// it back to them as ledgers and to landlords as statements
+type PaymentRepository interface {
+	// List returns the tenant's payments, newest first
+	List(ctx context.Context, organisationID string, tenantID string) ([]models.Payment, error)
+
+	// Create records a payment and moves the tenant's paid_to to the day it pays to.
+	// It fails with ErrAlreadyPaid when the tenant is already paid to that day.
+	Create(ctx context.Context, organisationID string, tenantID string, payload models.CreatePayment) (models.Payment, error)
+
+	// Ledger returns the tenant's rent position and payments. The currency is left
+	// for the caller, which has the organisation's settings.
+	Ledger(ctx context.Context, organisationID string, tenantID openapi_types.UUID) (models.RentLedger, error)
+
+	// Statement returns the payments received on the landlord's properties between
+	// from and to inclusive, less management fees. The currency is left for the
+	// caller, which has the organisation's settings.
+	Statement(ctx context.Context, organisationID string, landlordID openapi_types.UUID, from openapi_types.Date, to openapi_types.Date) (models.LandlordStatement, error)
+}
+
+// ErrAlreadyPaid is returned when a payment doesn't pay the tenant past the day
+// they're already paid to, which is how a payment recorded twice is caught
+var ErrAlreadyPaid = errors.New("already paid")
+
+// paymentColumns are selected in the order scanPayment expects
+const paymentColumns = `
+	id,
+	tenant_id,
+	amount,
+	received_on,
+	paid_from,
+	paid_to,
+	reference,
+	created_at
+`
+
+type PostgresPaymentRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresPaymentRepository(dbpool *pgxpool.Pool) *PostgresPaymentRepository {
+	return &PostgresPaymentRepository{dbpool: dbpool}
+}
+
+func (r *PostgresPaymentRepository) List(ctx context.Context, organisationID string, tenantID string) ([]models.Payment, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM payments
+		WHERE
+			tenant_id = $1
+			AND organisation_id = $2
+		ORDER BY received_on DESC, id DESC
+	`, paymentColumns)
+
+	return r.list(ctx, sql, tenantID, organisationID)
+}
+
+func (r *PostgresPaymentRepository) Create(ctx context.Context, organisationID string, tenantID string, payload models.CreatePayment) (models.Payment, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	// The tenant is locked so two payments for the same days can't both be recorded.
+	// A tenant who hasn't paid yet is paid from the start of their tenancy.
+	sql := fmt.Sprintf(`
+		WITH tenant AS (
+			SELECT
+				id,
+				organisation_id,
+				COALESCE(paid_to + 1, paid_from, start_date) AS paid_from
+			FROM tenants
+			WHERE
+				id = $1
+				AND organisation_id = $2
+				AND (paid_to IS NULL OR paid_to < $3)
+			FOR UPDATE
+		),
+		paid AS (
+			UPDATE tenants
+			SET
+				paid_to = $3,
+				updated_at = NOW()
+			FROM tenant
+			WHERE tenants.id = tenant.id
+		)
+		INSERT INTO payments (
+			id,
+			organisation_id,
+			tenant_id,
+			amount,
+			received_on,
+			paid_from,
+			paid_to,
+			reference
+		)
+		SELECT
+			$4,
+			organisation_id,
+			id,
+			$5,
+			$6,
+			paid_from,
+			$3,
+			$7
+		FROM tenant
+		RETURNING %s
+	`, paymentColumns)
+
+	row := r.dbpool.QueryRow(
+		ctx,
+		sql,
+		tenantID,
+		organisationID,
+		payload.PaidTo,
+		id.String(),
+		payload.Amount,
+		payload.ReceivedOn,
+		payload.Reference,
+	)
+
+	payment, err := scanPayment(row)
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return payment, err
+	}
+
+	// nothing was recorded, because the tenant is missing or already paid to the day
+	var exists bool
+
+	err = r.dbpool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM tenants
+			WHERE
+				id = $1
+				AND organisation_id = $2
+		)
+	`, tenantID, organisationID).Scan(&exists)
+
+	if err != nil {
+		return payment, err
+	}
+
+	if exists {
+		return payment, ErrAlreadyPaid
+	}
+
+	return payment, ErrNotFound
+}
+
+func (r *PostgresPaymentRepository) Ledger(ctx context.Context, organisationID string, tenantID openapi_types.UUID) (models.RentLedger, error) {
+	var ledger models.RentLedger
+
+	// arrears run to today in the organisation's timezone, or the day the tenant
+	// vacated if that's earlier
+	sql := fmt.Sprintf(`
+		WITH tenancy AS (
+			SELECT
+				%s,
+				LEAST(
+					(NOW() AT TIME ZONE COALESCE((SELECT timezone FROM organisations WHERE id = $2), 'Australia/Sydney'))::date,
+					COALESCE(vacate_date, 'infinity')
+				) - COALESCE(paid_to, start_date - 1) AS days_in_arrears
+			FROM tenants
+			WHERE
+				id = $1
+				AND organisation_id = $2
+		)
+		SELECT
+			%s,
+			GREATEST(days_in_arrears, 0),
+			COALESCE(GREATEST(days_in_arrears, 0) * %s, 0)::float8
+		FROM tenancy
+	`, portalTenancyColumns, portalTenancyColumns, dailyRent)
+
+	var daysInArrears int
+	row := r.dbpool.QueryRow(ctx, sql, tenantID, organisationID)
+
+	tenancy, err := scanPortalTenancy(&extraScanner{scanner: row, extra: []any{&daysInArrears, &ledger.ArrearsAmount}})
+
+	if err != nil {
+		return ledger, notFound(err)
+	}
+
+	ledger.Tenancy = tenancy
+	ledger.DaysInArrears = int32(daysInArrears)
+
+	ledger.Payments, err = r.List(ctx, organisationID, tenantID.String())
+
+	return ledger, err
+}
+
+func (r *PostgresPaymentRepository) Statement(ctx context.Context, organisationID string, landlordID openapi_types.UUID, from openapi_types.Date, to openapi_types.Date) (models.LandlordStatement, error) {
+	statement := models.LandlordStatement{
+		LandlordId: landlordID,
+		From:       from,
+		To:         to,
+		Items:      []models.StatementItem{},
+	}
+
+	// fees are rounded to the cent on each payment, and the totals are summed in the
+	// database so they match the items exactly
+	sql := `
+		WITH items AS (
+			SELECT
+				p.id,
+				t.property_id,
+				p.tenant_id,
+				p.received_on,
+				p.paid_from,
+				p.paid_to,
+				p.amount,
+				ROUND(p.amount * pr.management_fee / 100, 2) AS management_fee
+			FROM payments p
+			JOIN tenants t ON t.id = p.tenant_id
+			JOIN properties pr ON pr.id = t.property_id
+			WHERE
+				p.organisation_id = $1
+				AND pr.landlord_id = $2
+				AND p.received_on BETWEEN $3 AND $4
+		)
+		SELECT
+			id,
+			property_id,
+			tenant_id,
+			received_on,
+			paid_from,
+			paid_to,
+			amount::float8,
+			management_fee::float8,
+			(amount - management_fee)::float8,
+			SUM(amount) OVER ()::float8,
+			SUM(management_fee) OVER ()::float8,
+			SUM(amount - management_fee) OVER ()::float8
+		FROM items
+		ORDER BY received_on, id
+	`
+
+	rows, err := r.dbpool.Query(ctx, sql, organisationID, landlordID, from, to)
+
+	if err != nil {
+		return statement, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.StatementItem
+		var receivedOn pgtype.Date
+		var paidFrom pgtype.Date
+		var paidTo pgtype.Date
+
+		err := rows.Scan(
+			&item.PaymentId,
+			&item.PropertyId,
+			&item.TenantId,
+			&receivedOn,
+			&paidFrom,
+			&paidTo,
+			&item.Amount,
+			&item.ManagementFee,
+			&item.NetAmount,
+			&statement.TotalReceived,
+			&statement.TotalManagementFees,
+			&statement.TotalNetAmount,
+		)
+
+		if err != nil {
+			return statement, err
+		}
+
+		item.ReceivedOn.Time = receivedOn.Time
+		item.PaidFrom.Time = paidFrom.Time
+		item.PaidTo.Time = paidTo.Time
+
+		statement.Items = append(statement.Items, item)
+	}
+
+	return statement, rows.Err()
+}
+
+func (r *PostgresPaymentRepository) list(ctx context.Context, sql string, args ...interface{}) ([]models.Payment, error) {
+	payments := []models.Payment{}
+
+	rows, err := r.dbpool.Query(ctx, sql, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+func scanPayment(scanner scanner) (models.Payment, error) {
+	var payment models.Payment
+	var receivedOn pgtype.Date
+	var paidFrom pgtype.Date
+	var paidTo pgtype.Date
+
+	err := scanner.Scan(
+		&payment.Id,
+		&payment.TenantId,
+		&payment.Amount,
+		&receivedOn,
+		&paidFrom,
+		&paidTo,
+		&payment.Reference,
+		&payment.CreatedAt,
+	)
+
+	payment.ReceivedOn.Time = receivedOn.Time
+	payment.PaidFrom.Time = paidFrom.Time
+	payment.PaidTo.Time = paidTo.Time
+
+	return payment, err
+}
+
+// extraScanner scans the columns a scan function expects, followed by extra columns
+// selected after them
+type extraScanner struct {
+	scanner
+	extra []any
+}
+
+func (s *extraScanner) Scan(dest ...interface{}) error {
+	return s.scanner.Scan(append(dest, s.extra...)...)
+}
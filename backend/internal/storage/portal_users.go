@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PortalUserRepository reads and writes the portal access granted to landlords
+// and tenants
+type PortalUserRepository interface {
+	// List returns the organisation's portal users, sorted by the fields in sort
+	List(ctx context.Context, organisationID string, sort []string) ([]models.PortalUser, error)
+	Create(ctx context.Context, organisationID string, payload models.CreatePortalUser) (models.PortalUser, error)
+	Revoke(ctx context.Context, organisationID string, id string) (models.PortalUser, error)
+
+	// GetByUserID returns the portal identity of a user and the organisation it
+	// belongs to. Portal users aren't members of the organisation in Clerk, and can
+	// have access in several, so organisationID picks one. It can be left empty when
+	// the user only has access in one, otherwise ErrPortalOrganisationRequired is
+	// returned.
+	GetByUserID(ctx context.Context, userID string, organisationID string) (models.PortalUser, string, error)
+	// ListOrganisations returns the organisations a user has portal access in
+	ListOrganisations(ctx context.Context, userID string) ([]models.PortalOrganisation, error)
+}
+
+// ErrPortalOrganisationRequired is returned when a user has portal access in more
+// than one organisation and didn't say which to use
+var ErrPortalOrganisationRequired = errors.New("portal organisation required")
+
+// portalUserColumns are selected in the order scanPortalUser expects
+const portalUserColumns = `
+	id,
+	user_id,
+	landlord_id,
+	tenant_id,
+	created_at,
+	updated_at
+`
+
+// portalUserKeys is the order portal users are listed in when no sort is given
+var portalUserKeys = keyset{{column: "created_at"}, {column: "id"}}
+
+type PostgresPortalUserRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresPortalUserRepository(dbpool *pgxpool.Pool) *PostgresPortalUserRepository {
+	return &PostgresPortalUserRepository{dbpool: dbpool}
+}
+
+func (r *PostgresPortalUserRepository) List(ctx context.Context, organisationID string, sort []string) ([]models.PortalUser, error) {
+	portalUsers := []models.PortalUser{}
+
+	keys, err := sortKeys(sort, portalUserSortFields, portalUserKeys)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM portal_users
+		WHERE organisation_id = $1
+		%s
+	`, portalUserColumns, keys.orderBy(false))
+
+	rows, err := r.dbpool.Query(ctx, sql, organisationID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		portalUser, err := scanPortalUser(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		portalUsers = append(portalUsers, portalUser)
+	}
+
+	return portalUsers, rows.Err()
+}
+
+func (r *PostgresPortalUserRepository) Create(ctx context.Context, organisationID string, payload models.CreatePortalUser) (models.PortalUser, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.PortalUser{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO portal_users (
+			id,
+			user_id,
+			landlord_id,
+			tenant_id,
+			organisation_id
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5
+		) RETURNING %s
+	`, portalUserColumns)
+
+	row := r.dbpool.QueryRow(
+		ctx,
+		sql,
+		id.String(),
+		payload.UserId,
+		payload.LandlordId,
+		payload.TenantId,
+		organisationID,
+	)
+
+	return scanPortalUser(row)
+}
+
+func (r *PostgresPortalUserRepository) Revoke(ctx context.Context, organisationID string, id string) (models.PortalUser, error) {
+	sql := fmt.Sprintf(`
+		DELETE FROM portal_users
+		WHERE
+			id = $1
+			AND organisation_id = $2
+		RETURNING %s
+	`, portalUserColumns)
+
+	row := r.dbpool.QueryRow(ctx, sql, id, organisationID)
+
+	portalUser, err := scanPortalUser(row)
+
+	return portalUser, notFound(err)
+}
+
+func (r *PostgresPortalUserRepository) GetByUserID(ctx context.Context, userID string, organisationID string) (models.PortalUser, string, error) {
+	var portalUser models.PortalUser
+
+	// a second row means the organisation has to be picked
+	sql := fmt.Sprintf(`
+		SELECT %s, organisation_id
+		FROM portal_users
+		WHERE
+			user_id = $1
+			AND ($2 = '' OR organisation_id = $2)
+		LIMIT 2
+	`, portalUserColumns)
+
+	rows, err := r.dbpool.Query(ctx, sql, userID, organisationID)
+
+	if err != nil {
+		return portalUser, "", err
+	}
+	defer rows.Close()
+
+	found := 0
+
+	for rows.Next() {
+		found++
+
+		err := rows.Scan(
+			&portalUser.Id,
+			&portalUser.UserId,
+			&portalUser.LandlordId,
+			&portalUser.TenantId,
+			&portalUser.CreatedAt,
+			&portalUser.UpdatedAt,
+			&organisationID,
+		)
+
+		if err != nil {
+			return portalUser, "", err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return portalUser, "", err
+	}
+
+	switch found {
+	case 0:
+		return models.PortalUser{}, "", ErrNotFound
+	case 1:
+		return portalUser, organisationID, nil
+	default:
+		return models.PortalUser{}, "", ErrPortalOrganisationRequired
+	}
+}
+
+func (r *PostgresPortalUserRepository) ListOrganisations(ctx context.Context, userID string) ([]models.PortalOrganisation, error) {
+	organisations := []models.PortalOrganisation{}
+
+	// the organisation's settings row is only created once it's been read or written
+	rows, err := r.dbpool.Query(ctx, `
+		SELECT
+			p.organisation_id,
+			o.trading_name,
+			CASE WHEN p.landlord_id IS NOT NULL THEN 'landlord' ELSE 'tenant' END
+		FROM portal_users p
+		LEFT JOIN organisations o ON o.id = p.organisation_id
+		WHERE p.user_id = $1
+		ORDER BY p.organisation_id
+	`, userID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var organisation models.PortalOrganisation
+
+		if err := rows.Scan(&organisation.OrganisationId, &organisation.TradingName, &organisation.Role); err != nil {
+			return nil, err
+		}
+
+		organisations = append(organisations, organisation)
+	}
+
+	return organisations, rows.Err()
+}
+
+func scanPortalUser(scanner scanner) (models.PortalUser, error) {
+	var portalUser models.PortalUser
+
+	err := scanner.Scan(
+		&portalUser.Id,
+		&portalUser.UserId,
+		&portalUser.LandlordId,
+		&portalUser.TenantId,
+		&portalUser.CreatedAt,
+		&portalUser.UpdatedAt,
+	)
+
+	return portalUser, err
+}
@@ -0,0 +1,500 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// PropertyRepository reads and writes properties within an organisation
+type PropertyRepository interface {
+	List(ctx context.Context, organisationID string, filter PropertyFilter) ([]models.Property, PageInfo, error)
+	// ListVersion returns the version of the records List would return for the filter,
+	// ignoring its page, sort and fields
+	ListVersion(ctx context.Context, organisationID string, filter PropertyFilter) (ListVersion, error)
+	Get(ctx context.Context, organisationID string, id string) (models.Property, error)
+	// GetMany returns the properties with the given IDs, leaving out any that don't exist
+	GetMany(ctx context.Context, organisationID string, ids []string) ([]models.Property, error)
+	Create(ctx context.Context, organisationID string, payload models.CreateProperty) (models.Property, error)
+	// Update and Archive fail with ErrPreconditionFailed when the property isn't at one of
+	// the given versions
+	Update(ctx context.Context, organisationID string, id string, payload models.UpdateProperty, versions Versions) (models.Property, error)
+	Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Property, error)
+
+	// ListForPortalUser returns the properties a portal user owns or rents
+	ListForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.PortalProperty, error)
+
+	// PortfolioSummary summarises the properties assigned to a property manager
+	PortfolioSummary(ctx context.Context, organisationID string, userID string) (models.PortfolioSummary, error)
+}
+
+// PropertyFilter narrows and pages the properties returned by List
+type PropertyFilter struct {
+	Address      *string
+	ArchivedOnly *bool
+	AssignedTo   *string
+	LandlordID   Set
+	Suburb       Set
+	State        Set
+	Postcode     Set
+	// ManagementGained is compared as a date
+	ManagementGained Range[time.Time]
+	ManagementFee    Range[float64]
+	// Sort is the fields to sort by, e.g. -created_at for the newest first
+	Sort []string
+	// Fields limits the columns selected to these and id, all of them when empty
+	Fields []string
+	Page
+}
+
+// propertyColumns are selected in the order scanProperty expects
+const propertyColumns = `
+	id,
+	street_number,
+	street_name,
+	suburb,
+	state,
+	postcode,
+	country,
+	landlord_id,
+	assigned_to,
+	management_fee,
+	management_gained,
+	management_lost,
+	is_archived,
+	created_at,
+	updated_at
+`
+
+// propertyKeys is the order lists of properties are returned in when no sort is given
+var propertyKeys = keyset{{column: "street_name"}, {column: "street_number"}, {column: "id"}}
+
+type PostgresPropertyRepository struct {
+	db querier
+}
+
+func NewPostgresPropertyRepository(dbpool *pgxpool.Pool) *PostgresPropertyRepository {
+	return &PostgresPropertyRepository{db: dbpool}
+}
+
+func (r *PostgresPropertyRepository) List(ctx context.Context, organisationID string, filter PropertyFilter) ([]models.Property, PageInfo, error) {
+	keys, err := sortKeys(filter.Sort, propertySortFields, propertyKeys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	columns, err := selectColumns(propertyColumns, propertyPlaceholders, filter.Fields, keys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	whereClause, queryParams, paramCount := buildWhereClause(propertyConditions(organisationID, filter))
+
+	return listPage(ctx, r.db, listQuery{
+		table:      "properties",
+		columns:    columns,
+		where:      whereClause,
+		params:     queryParams,
+		paramCount: paramCount,
+		keys:       keys,
+	}, filter.Page, scanProperty)
+}
+
+func (r *PostgresPropertyRepository) ListVersion(ctx context.Context, organisationID string, filter PropertyFilter) (ListVersion, error) {
+	whereClause, queryParams, paramCount := buildWhereClause(propertyConditions(organisationID, filter))
+
+	return listVersion(ctx, r.db, "properties", organisationID, whereClause, queryParams, paramCount)
+}
+
+// propertyConditions are the columns List matches the filter against
+func propertyConditions(organisationID string, filter PropertyFilter) map[string]interface{} {
+	return map[string]interface{}{
+		"full_address":      filter.Address,
+		"archived_only":     filter.ArchivedOnly,
+		"assigned_to":       filter.AssignedTo,
+		"landlord_id":       filter.LandlordID,
+		"suburb":            filter.Suburb,
+		"state":             filter.State,
+		"postcode":          filter.Postcode,
+		"management_gained": filter.ManagementGained,
+		"management_fee":    filter.ManagementFee,
+		"organisation_id":   organisationID,
+	}
+}
+
+func (r *PostgresPropertyRepository) Get(ctx context.Context, organisationID string, id string) (models.Property, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM properties
+		WHERE
+			id = $1
+			AND organisation_id = $2
+	`, propertyColumns)
+
+	row := r.db.QueryRow(ctx, sql, id, organisationID)
+
+	property, err := scanProperty(row)
+
+	return property, notFound(err)
+}
+
+func (r *PostgresPropertyRepository) GetMany(ctx context.Context, organisationID string, ids []string) ([]models.Property, error) {
+	return getMany(ctx, r.db, "properties", propertyColumns, organisationID, ids, scanProperty)
+}
+
+func (r *PostgresPropertyRepository) Create(ctx context.Context, organisationID string, payload models.CreateProperty) (models.Property, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.Property{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO properties (
+			id,
+			street_number,
+			street_name,
+			suburb,
+			state,
+			postcode,
+			country,
+			landlord_id,
+			management_fee,
+			management_gained,
+			organisation_id,
+			assigned_to
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			COALESCE($9, (SELECT default_management_fee FROM organisations WHERE id = $11)),
+			$10,
+			$11,
+			$12
+		) RETURNING %s
+	`, propertyColumns)
+
+	row := r.db.QueryRow(
+		ctx,
+		sql,
+		id.String(),
+		payload.StreetNumber,
+		payload.StreetName,
+		payload.Suburb,
+		payload.State,
+		payload.Postcode,
+		payload.Country,
+		payload.LandlordId,
+		payload.ManagementFee,
+		payload.ManagementGained,
+		organisationID,
+		payload.AssignedTo,
+	)
+
+	return scanProperty(row)
+}
+
+func (r *PostgresPropertyRepository) Update(ctx context.Context, organisationID string, id string, payload models.UpdateProperty, versions Versions) (models.Property, error) {
+	setClause, values, paramCount := buildPropertyUpdateSetClause(payload)
+	values = append(values, id, organisationID)
+
+	versionClause, versionParams := versions.and(paramCount + 3)
+	values = append(values, versionParams...)
+
+	sql := fmt.Sprintf(`
+		UPDATE properties
+		%s
+		WHERE
+			id = $%d
+			AND organisation_id = $%d
+			%s
+		RETURNING %s
+	`, setClause, paramCount+1, paramCount+2, versionClause, propertyColumns)
+
+	row := r.db.QueryRow(ctx, sql, values...)
+
+	property, err := scanProperty(row)
+
+	return property, conditionalWriteError(ctx, r.db, "properties", organisationID, id, versions, err)
+}
+
+func (r *PostgresPropertyRepository) Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Property, error) {
+	versionClause, versionParams := versions.and(3)
+
+	sql := fmt.Sprintf(`
+		UPDATE properties
+		SET
+			is_archived = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = $1
+			AND organisation_id = $2
+			%s
+		RETURNING %s
+	`, versionClause, propertyColumns)
+
+	row := r.db.QueryRow(ctx, sql, append([]interface{}{id, organisationID}, versionParams...)...)
+
+	property, err := scanProperty(row)
+
+	return property, conditionalWriteError(ctx, r.db, "properties", organisationID, id, versions, err)
+}
+
+// ListForPortalUser relies on the unset landlord or tenant ID being NULL, which
+// never matches, so the one query covers both kinds of portal user
+func (r *PostgresPropertyRepository) ListForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.PortalProperty, error) {
+	properties := []models.PortalProperty{}
+
+	// tenants only see the property while their tenancy is current
+	sql := fmt.Sprintf(`
+		SELECT
+			id,
+			street_number,
+			street_name,
+			suburb,
+			postcode,
+			state,
+			country
+		FROM properties
+		WHERE
+			organisation_id = $1
+			AND is_archived IS NULL
+			AND (
+				landlord_id = $2
+				OR id IN (
+					SELECT property_id
+					FROM tenants
+					WHERE
+						id = $3
+						AND %s
+				)
+			)
+		ORDER BY street_name, street_number
+	`, activeTenancy(1))
+
+	rows, err := r.db.Query(
+		ctx,
+		sql,
+		organisationID,
+		portalUser.LandlordId,
+		portalUser.TenantId,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var property models.PortalProperty
+
+		err := rows.Scan(
+			&property.Id,
+			&property.StreetNumber,
+			&property.StreetName,
+			&property.Suburb,
+			&property.Postcode,
+			&property.State,
+			&property.Country,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		properties = append(properties, property)
+	}
+
+	return properties, rows.Err()
+}
+
+func (r *PostgresPropertyRepository) PortfolioSummary(ctx context.Context, organisationID string, userID string) (models.PortfolioSummary, error) {
+	// "today" is in the organisation's timezone so that arrears and lease expiry
+	// line up with the dates on the tenancies
+	sql := `
+		WITH today AS (
+			SELECT (NOW() AT TIME ZONE COALESCE(
+				(SELECT timezone FROM organisations WHERE id = $1),
+				'Australia/Sydney'
+			))::date AS date
+		),
+		portfolio AS (
+			SELECT id
+			FROM properties
+			WHERE
+				organisation_id = $1
+				AND assigned_to = $2
+				AND is_archived IS NULL
+		),
+		tenancies AS (
+			SELECT property_id, paid_to, end_date
+			FROM tenants
+			WHERE
+				organisation_id = $1
+				AND property_id IN (SELECT id FROM portfolio)
+				AND is_archived IS NULL
+				AND (vacate_date IS NULL OR vacate_date >= (SELECT date FROM today))
+		)
+		SELECT
+			(SELECT COUNT(*) FROM portfolio),
+			(SELECT COUNT(*) FROM portfolio p WHERE NOT EXISTS (
+				SELECT 1 FROM tenancies t WHERE t.property_id = p.id
+			)),
+			(SELECT COUNT(*) FROM tenancies),
+			(SELECT COUNT(*) FROM tenancies WHERE paid_to < (SELECT date FROM today)),
+			(SELECT COUNT(*) FROM tenancies WHERE end_date BETWEEN (SELECT date FROM today) AND (SELECT date FROM today) + 60)
+	`
+
+	summary := models.PortfolioSummary{
+		UserId: userID,
+	}
+
+	err := r.db.QueryRow(
+		ctx,
+		sql,
+		organisationID,
+		userID,
+	).Scan(
+		&summary.Properties,
+		&summary.VacantProperties,
+		&summary.ActiveTenancies,
+		&summary.TenanciesInArrears,
+		&summary.LeasesExpiring,
+	)
+
+	return summary, err
+}
+
+func scanProperty(scanner scanner) (models.Property, error) {
+	var property models.Property
+
+	var managementGained pgtype.Date
+	var managementLost *pgtype.Date
+
+	err := scanner.Scan(
+		&property.Id,
+		&property.StreetNumber,
+		&property.StreetName,
+		&property.Suburb,
+		&property.State,
+		&property.Postcode,
+		&property.Country,
+		&property.LandlordId,
+		&property.AssignedTo,
+		&property.ManagementFee,
+		&managementGained,
+		&managementLost,
+		&property.IsArchived,
+		&property.CreatedAt,
+		&property.UpdatedAt,
+	)
+
+	if err != nil {
+		return property, err
+	}
+
+	property.ManagementGained = openapi_types.Date{
+		Time: managementGained.Time,
+	}
+
+	if managementLost != nil {
+		property.ManagementLost = &openapi_types.Date{
+			Time: managementLost.Time,
+		}
+	}
+
+	return property, nil
+}
+
+func buildPropertyUpdateSetClause(payload models.UpdateProperty) (string, []interface{}, int) {
+	fields := []string{}
+	values := []interface{}{}
+	paramCount := 0
+
+	if payload.StreetNumber != nil && *payload.StreetNumber != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("street_number = $%d", paramCount))
+		values = append(values, *payload.StreetNumber)
+	}
+
+	if payload.StreetName != nil && *payload.StreetName != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("street_name = $%d", paramCount))
+		values = append(values, *payload.StreetName)
+	}
+
+	if payload.Suburb != nil && *payload.Suburb != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("suburb = $%d", paramCount))
+		values = append(values, *payload.Suburb)
+	}
+
+	if payload.Postcode != nil && *payload.Postcode != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("postcode = $%d", paramCount))
+		values = append(values, *payload.Postcode)
+	}
+
+	if payload.State != nil && *payload.State != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("state = $%d", paramCount))
+		values = append(values, *payload.State)
+	}
+
+	if payload.Country != nil && *payload.Country != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("country = $%d", paramCount))
+		values = append(values, *payload.Country)
+	}
+
+	if payload.ManagementFee != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("management_fee = $%d", paramCount))
+		values = append(values, *payload.ManagementFee)
+	}
+
+	if payload.ManagementGained != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("management_gained = $%d", paramCount))
+		values = append(values, *payload.ManagementGained)
+	}
+
+	if payload.ManagementLost.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("management_lost = $%d", paramCount))
+		values = append(values, nullableValue(payload.ManagementLost))
+	}
+
+	// an empty string unassigns the property too, as it did before null could be sent
+	if payload.AssignedTo.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("assigned_to = NULLIF($%d, '')", paramCount))
+		values = append(values, nullableValue(payload.AssignedTo))
+	}
+
+	if payload.IsArchived.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("is_archived = $%d", paramCount))
+		values = append(values, nullableValue(payload.IsArchived))
+	}
+
+	fields = append(fields, "updated_at = NOW()")
+
+	setClause := "SET\n" + strings.Join(fields, ",\n")
+
+	return setClause, values, paramCount
+}
@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SearchRepository finds landlords, properties and tenants from a single query, for
+// the omnibox
+type SearchRepository interface {
+	Search(ctx context.Context, organisationID string, filter SearchFilter) ([]models.SearchHit, error)
+}
+
+type SearchFilter struct {
+	Query string
+	// Types limits the hits to these types of records, all of them when empty
+	Types []models.SearchResultType
+	Limit int
+}
+
+// searchField is a column matched against the query. Mobiles are matched on their
+// digits, so 0412 345 678 is found by 0412345678 and the other way round.
+type searchField struct {
+	name   models.SearchField
+	column string
+	digits bool
+}
+
+// searchSource is a table searched for one type of record
+type searchSource struct {
+	resultType models.SearchResultType
+	table      string
+	title      string
+	fields     []searchField
+}
+
+var searchSources = []searchSource{
+	{
+		resultType: models.SearchResultTypeLandlord,
+		table:      "landlords",
+		title:      "name",
+		fields: []searchField{
+			{name: models.Name, column: "name"},
+			{name: models.Email, column: "email"},
+			{name: models.Mobile, column: "mobile", digits: true},
+		},
+	},
+	{
+		resultType: models.SearchResultTypeProperty,
+		table:      "properties",
+		title:      "full_address",
+		fields: []searchField{
+			{name: models.Address, column: "full_address"},
+		},
+	},
+	{
+		resultType: models.SearchResultTypeTenant,
+		table:      "tenants",
+		title:      "name",
+		fields: []searchField{
+			{name: models.Name, column: "name"},
+			{name: models.Email, column: "email"},
+			{name: models.Mobile, column: "mobile", digits: true},
+		},
+	},
+}
+
+// minSearchDigits is the fewest digits that are matched against mobiles, so a
+// query like "Unit 4" doesn't match every mobile with a 4 in it
+const minSearchDigits = 3
+
+type PostgresSearchRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresSearchRepository(dbpool *pgxpool.Pool) *PostgresSearchRepository {
+	return &PostgresSearchRepository{dbpool: dbpool}
+}
+
+// Search returns the records with a field matching the query, best match first.
+// Text fields match when the query is similar to a word in them, using pg_trgm's
+// word similarity so that typos are forgiven, or when they contain the query.
+// Archived records aren't returned.
+func (r *PostgresSearchRepository) Search(ctx context.Context, organisationID string, filter SearchFilter) ([]models.SearchHit, error) {
+	hits := []models.SearchHit{}
+
+	digits := digitsOf(filter.Query)
+	withDigits := len(digits) >= minSearchDigits
+
+	params := []interface{}{organisationID, filter.Query, "%" + escapeLike(filter.Query) + "%", filter.Limit}
+	selects := []string{}
+	usesDigits := false
+
+	for _, source := range searchSources {
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, source.resultType) {
+			continue
+		}
+
+		sql, used := source.query(withDigits)
+		selects = append(selects, sql)
+		usesDigits = usesDigits || used
+	}
+
+	// Postgres can't type a parameter that isn't used, so it's only passed when needed
+	if usesDigits {
+		params = append(params, "%"+digits+"%")
+	}
+
+	sql := fmt.Sprintf(`
+		%s
+		ORDER BY score DESC, title, id
+		LIMIT $4
+	`, strings.Join(selects, "\nUNION ALL\n"))
+
+	rows, err := r.dbpool.Query(ctx, sql, params...)
+
+	if err != nil {
+		return hits, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hit models.SearchHit
+		var value string
+
+		err := rows.Scan(&hit.Type, &hit.Id, &hit.Title, &hit.Field, &value, &hit.Score)
+
+		if err != nil {
+			return hits, err
+		}
+
+		hit.Highlight = highlight(value, filter.Query, hit.Field == models.Mobile)
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// query selects the source's records that match, with the field that matches best.
+// The parameters are the organisation, the query and the query as a LIKE pattern,
+// then $5 is the query's digits as a LIKE pattern. Mobiles are only matched when
+// withDigits is set, and the returned bool reports whether $5 was used.
+func (s searchSource) query(withDigits bool) (string, bool) {
+	scores := []string{}
+	matches := []string{}
+	usesDigits := false
+
+	for _, field := range s.fields {
+		if field.digits {
+			if !withDigits {
+				continue
+			}
+
+			// the index on the mobile's digits is built from the same expression
+			match := fmt.Sprintf("regexp_replace(%s, '[^0-9]', '', 'g') LIKE $5", field.column)
+			scores = append(scores, fmt.Sprintf("('%s', %s, CASE WHEN %s THEN 1 ELSE 0 END)", field.name, field.column, match))
+			matches = append(matches, match)
+			usesDigits = true
+
+			continue
+		}
+
+		scores = append(scores, fmt.Sprintf("('%s', %s, word_similarity($2, %s))", field.name, field.column, field.column))
+		matches = append(matches, fmt.Sprintf("$2 <%% %s", field.column), fmt.Sprintf("%s ILIKE $3", field.column))
+	}
+
+	sql := fmt.Sprintf(`
+		(SELECT '%s' AS type, t.id::text AS id, t.%s AS title, best.field, best.value, best.score::float8 AS score
+		FROM %s t
+		CROSS JOIN LATERAL (
+			SELECT field, value, score
+			FROM (VALUES %s) AS f(field, value, score)
+			ORDER BY score DESC
+			LIMIT 1
+		) best
+		WHERE
+			t.organisation_id = $1
+			AND t.is_archived IS NULL
+			AND (%s))
+	`, s.resultType, s.title, s.table, strings.Join(scores, ", "), strings.Join(matches, " OR "))
+
+	return sql, usesDigits
+}
+
+// highlight splits a value into the parts that do and don't contain a word of the
+// query, ignoring case. With digits set only the query's digits are matched, and
+// anything between them in the value, such as spaces in a mobile, is matched too.
+// Values found by similarity alone have no matching parts.
+func highlight(value string, query string, digits bool) []models.HighlightSegment {
+	runes := []rune(value)
+	matched := make([]bool, len(runes))
+
+	if digits {
+		positions := []int{}
+		valueDigits := []rune{}
+
+		for i, r := range runes {
+			if isDigit(r) {
+				positions = append(positions, i)
+				valueDigits = append(valueDigits, r)
+			}
+		}
+
+		if queryDigits := []rune(digitsOf(query)); len(queryDigits) > 0 {
+			for _, start := range indexesOf(valueDigits, queryDigits) {
+				end := start + len(queryDigits) - 1
+
+				for i := positions[start]; i <= positions[end]; i++ {
+					matched[i] = true
+				}
+			}
+		}
+	} else {
+		lower := []rune(strings.Map(unicode.ToLower, value))
+
+		for _, word := range strings.Fields(strings.Map(unicode.ToLower, query)) {
+			word := []rune(word)
+
+			for _, start := range indexesOf(lower, word) {
+				for i := start; i < start+len(word); i++ {
+					matched[i] = true
+				}
+			}
+		}
+	}
+
+	segments := []models.HighlightSegment{}
+
+	for i, r := range runes {
+		if len(segments) > 0 && segments[len(segments)-1].Matched == matched[i] {
+			segments[len(segments)-1].Text += string(r)
+			continue
+		}
+
+		segments = append(segments, models.HighlightSegment{Text: string(r), Matched: matched[i]})
+	}
+
+	return segments
+}
+
+// indexesOf returns where each occurrence of sub starts in s, including overlapping
+// occurrences
+func indexesOf(s []rune, sub []rune) []int {
+	indexes := []int{}
+
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func digitsOf(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isDigit(r) {
+			return r
+		}
+
+		return -1
+	}, s)
+}
+
+// escapeLike escapes the characters that are special in a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/davidtaing/property-management/internal/models"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		query  string
+		digits bool
+		want   []models.HighlightSegment
+	}{
+		{
+			name:  "words in any case",
+			value: "10 Pitt Street Sydney",
+			query: "pitt SYD",
+			want: []models.HighlightSegment{
+				{Text: "10 "},
+				{Text: "Pitt", Matched: true},
+				{Text: " Street "},
+				{Text: "Syd", Matched: true},
+				{Text: "ney"},
+			},
+		},
+		{
+			name:  "similar but not contained",
+			value: "Jane Citizen",
+			query: "citisen",
+			want:  []models.HighlightSegment{{Text: "Jane Citizen"}},
+		},
+		{
+			name:   "digits across spaces",
+			value:  "0412 345 678",
+			query:  "2345",
+			digits: true,
+			want: []models.HighlightSegment{
+				{Text: "041"},
+				{Text: "2 345", Matched: true},
+				{Text: " 678"},
+			},
+		},
+		{
+			name:   "no digits",
+			value:  "0412 345 678",
+			query:  "jane",
+			digits: true,
+			want:   []models.HighlightSegment{{Text: "0412 345 678"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.value, tt.query, tt.digits); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got := escapeLike(`50%_off\`); got != `50\%\_off\\` {
+		t.Errorf("expected the LIKE wildcards to be escaped, got %s", got)
+	}
+}
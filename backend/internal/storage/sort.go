@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSort is returned when a list is sorted by a field that can't be sorted on
+var ErrInvalidSort = errors.New("invalid sort")
+
+// Fields each list can be sorted by. They're named the same as the columns, and as
+// the JSON fields of the models.
+var (
+	landlordSortFields           = []string{"name", "email", "suburb", "state", "postcode", "created_at", "updated_at"}
+	propertySortFields           = []string{"street_name", "street_number", "suburb", "state", "postcode", "management_fee", "management_gained", "created_at", "updated_at"}
+	tenantSortFields             = []string{"name", "email", "paid_to", "start_date", "end_date", "rental_amount", "created_at", "updated_at"}
+	portalUserSortFields         = []string{"user_id", "created_at", "updated_at"}
+	maintenanceRequestSortFields = []string{"title", "status", "created_at", "updated_at"}
+)
+
+// nullableSortFields are the sort fields whose columns can be null
+var nullableSortFields = []string{"management_gained", "paid_to", "rental_amount"}
+
+// sortKeys returns the order given by fields such as -paid_to, where - sorts in
+// descending order. id is added to break ties, and defaults is used when no fields
+// are given.
+func sortKeys(fields []string, sortable []string, defaults keyset) (keyset, error) {
+	if len(fields) == 0 {
+		return defaults, nil
+	}
+
+	keys := keyset{}
+	seen := map[string]bool{}
+
+	for _, field := range fields {
+		key := sortKey{column: field}
+
+		if column, ok := strings.CutPrefix(field, "-"); ok {
+			key = sortKey{column: column, desc: true}
+		}
+
+		key.nullable = slices.Contains(nullableSortFields, key.column)
+
+		if !slices.Contains(sortable, key.column) || seen[key.column] {
+			return nil, ErrInvalidSort
+		}
+
+		seen[key.column] = true
+		keys = append(keys, key)
+	}
+
+	return append(keys, sortKey{column: "id"}), nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatsRepository reports totals across every organisation. It's used for metrics,
+// so unlike the other repositories it isn't scoped to an organisation.
+type StatsRepository interface {
+	// TenancyStats returns the totals for each organisation tier and currency
+	TenancyStats(ctx context.Context) ([]TenancyStats, error)
+}
+
+type TenancyStats struct {
+	Tier               string
+	Currency           string
+	ActiveTenancies    int
+	TenanciesInArrears int
+	// ArrearsAmount is the rent owed for the days since each tenancy was paid to, in
+	// Currency
+	ArrearsAmount float64
+}
+
+type PostgresStatsRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresStatsRepository(dbpool *pgxpool.Pool) *PostgresStatsRepository {
+	return &PostgresStatsRepository{dbpool: dbpool}
+}
+
+func (r *PostgresStatsRepository) TenancyStats(ctx context.Context) ([]TenancyStats, error) {
+	stats := []TenancyStats{}
+
+	// "today" is in each organisation's timezone, the same as the portfolio summary.
+	// Organisations without a settings row have the default tier, currency and
+	// timezone.
+	sql := fmt.Sprintf(`
+		WITH organisation_tenants AS (
+			SELECT
+				COALESCE(o.tier, 'standard') AS tier,
+				COALESCE(o.currency, 'AUD') AS currency,
+				(NOW() AT TIME ZONE COALESCE(o.timezone, 'Australia/Sydney'))::date AS today,
+				t.rental_amount,
+				t.frequency,
+				t.paid_to,
+				t.vacate_date,
+				t.is_archived
+			FROM tenants t
+			LEFT JOIN organisations o ON o.id = t.organisation_id
+		),
+		tenancies AS (
+			SELECT
+				tier,
+				currency,
+				rental_amount,
+				frequency,
+				today - paid_to AS days_in_arrears
+			FROM organisation_tenants
+			WHERE
+				is_archived IS NULL
+				AND (vacate_date IS NULL OR vacate_date >= today)
+		)
+		SELECT
+			tier,
+			currency,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE days_in_arrears > 0),
+			COALESCE(SUM(days_in_arrears * %s) FILTER (WHERE days_in_arrears > 0), 0)::float8
+		FROM tenancies
+		GROUP BY tier, currency
+	`, dailyRent)
+
+	rows, err := r.dbpool.Query(ctx, sql)
+
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s TenancyStats
+
+		err := rows.Scan(&s.Tier, &s.Currency, &s.ActiveTenancies, &s.TenanciesInArrears, &s.ArrearsAmount)
+
+		if err != nil {
+			return stats, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,189 @@
+// Package storage contains the repositories used by the API to read and write
+// records, and their Postgres implementations.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/oapi-codegen/nullable"
+)
+
+// ErrNotFound is returned when a record doesn't exist in the caller's organisation
+var ErrNotFound = errors.New("not found")
+
+// Repositories groups the repositories the API depends on
+type Repositories struct {
+	Landlords           LandlordRepository
+	Properties          PropertyRepository
+	Tenants             TenantRepository
+	Organisations       OrganisationRepository
+	PortalUsers         PortalUserRepository
+	MaintenanceRequests MaintenanceRequestRepository
+	Payments            PaymentRepository
+	Search              SearchRepository
+	Idempotency         IdempotencyRepository
+	Transactor          Transactor
+}
+
+// NewPostgresRepositories returns repositories backed by the Postgres pool
+func NewPostgresRepositories(dbpool *pgxpool.Pool) Repositories {
+	return Repositories{
+		Landlords:           NewPostgresLandlordRepository(dbpool),
+		Properties:          NewPostgresPropertyRepository(dbpool),
+		Tenants:             NewPostgresTenantRepository(dbpool),
+		Organisations:       NewPostgresOrganisationRepository(dbpool),
+		PortalUsers:         NewPostgresPortalUserRepository(dbpool),
+		MaintenanceRequests: NewPostgresMaintenanceRequestRepository(dbpool),
+		Payments:            NewPostgresPaymentRepository(dbpool),
+		Search:              NewPostgresSearchRepository(dbpool),
+		Idempotency:         NewPostgresIdempotencyRepository(dbpool),
+		Transactor:          NewPostgresTransactor(dbpool),
+	}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// querier runs statements on either the pool or a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// notFound replaces pgx.ErrNoRows with ErrNotFound so callers don't depend on pgx
+func notFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// nullableValue returns the value of an update's field that can be cleared, or nil when
+// it's set to null
+func nullableValue[T any](field nullable.Nullable[T]) *T {
+	if value, err := field.Get(); err == nil {
+		return &value
+	}
+
+	return nil
+}
+
+// getMany returns the records with the given IDs, in no particular order. IDs that
+// don't belong to the organisation are left out.
+func getMany[T any](
+	ctx context.Context,
+	db querier,
+	table string,
+	columns string,
+	organisationID string,
+	ids []string,
+	scan func(scanner) (T, error),
+) ([]T, error) {
+	items := []T{}
+
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE
+			id = ANY($1::uuid[])
+			AND organisation_id = $2
+	`, columns, table)
+
+	rows, err := db.Query(ctx, sql, ids, organisationID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scan(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// buildWhereClause constructs a SQL WHERE clause from a map of conditions
+// conditions is a map of column names to their search values. Values can also be a
+// Range or Set, which match a range or any of a list of values.
+//
+// Returns the WHERE clause string, query parameters, and the next parameter number
+func buildWhereClause(conditions map[string]interface{}) (string, []interface{}, int) {
+	if len(conditions) == 0 {
+		return "", []interface{}{}, 1
+	}
+
+	clauses := []string{}
+	params := []interface{}{}
+	paramCount := 1
+
+	for column, value := range conditions {
+		if value == nil {
+			continue
+		}
+
+		switch column {
+		case "name", "full_address":
+			if v, ok := value.(*string); ok && v != nil {
+				clauses = append(clauses, fmt.Sprintf("%s ILIKE $%d", column, paramCount))
+				params = append(params, "%"+*v+"%")
+				paramCount++
+			}
+		case "assigned_to":
+			if v, ok := value.(*string); ok && v != nil {
+				clauses = append(clauses, fmt.Sprintf("assigned_to = $%d", paramCount))
+				params = append(params, *v)
+				paramCount++
+			}
+		case "property_assigned_to":
+			if v, ok := value.(*string); ok && v != nil {
+				clauses = append(clauses, fmt.Sprintf("property_id IN (SELECT id FROM properties WHERE assigned_to = $%d)", paramCount))
+				params = append(params, *v)
+				paramCount++
+			}
+		case "archived_only":
+			if v, ok := value.(*bool); ok && v != nil && *v {
+				clauses = append(clauses, "is_archived is not null")
+			} else {
+				clauses = append(clauses, "is_archived is null")
+			}
+		default:
+			if c, ok := value.(condition); ok {
+				conditionClauses, conditionParams, next := c.where(column, paramCount)
+				clauses = append(clauses, conditionClauses...)
+				params = append(params, conditionParams...)
+				paramCount = next
+				continue
+			}
+
+			clauses = append(clauses, fmt.Sprintf("%s = $%d", column, paramCount))
+			params = append(params, value)
+			paramCount++
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "", []interface{}{}, 1
+	}
+
+	return "WHERE " + strings.Join(clauses, "\nAND "), params, paramCount
+}
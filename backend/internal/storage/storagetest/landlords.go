@@ -0,0 +1,247 @@
+// Package storagetest contains in-memory repositories for testing the API's handlers
+// without Postgres. They keep to the contracts of the storage interfaces, but don't
+// sort, page or filter beyond what's noted.
+package storagetest
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/davidtaing/property-management/internal/storage"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+)
+
+// LandlordRepository keeps landlords in memory. List returns them in the order they
+// were created, filtered by organisation and ArchivedOnly, in a single page.
+type LandlordRepository struct {
+	mu        sync.Mutex
+	landlords []landlord
+}
+
+type landlord struct {
+	organisationID string
+	record         models.Landlord
+}
+
+var _ storage.LandlordRepository = (*LandlordRepository)(nil)
+
+func NewLandlordRepository() *LandlordRepository {
+	return &LandlordRepository{}
+}
+
+func (r *LandlordRepository) List(ctx context.Context, organisationID string, filter storage.LandlordFilter) ([]models.Landlord, storage.PageInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	landlords := r.list(organisationID, filter)
+	total := len(landlords)
+
+	return landlords, storage.PageInfo{Total: &total}, nil
+}
+
+func (r *LandlordRepository) ListVersion(ctx context.Context, organisationID string, filter storage.LandlordFilter) (storage.ListVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var version storage.ListVersion
+
+	for _, l := range r.landlords {
+		if l.organisationID == organisationID && l.record.UpdatedAt.After(version.LastModified) {
+			version.LastModified = l.record.UpdatedAt
+		}
+	}
+
+	for _, l := range r.list(organisationID, filter) {
+		version.Count++
+
+		if l.UpdatedAt.After(version.UpdatedAt) {
+			version.UpdatedAt = l.UpdatedAt
+		}
+	}
+
+	return version, nil
+}
+
+func (r *LandlordRepository) list(organisationID string, filter storage.LandlordFilter) []models.Landlord {
+	archivedOnly := filter.ArchivedOnly != nil && *filter.ArchivedOnly
+	landlords := []models.Landlord{}
+
+	for _, l := range r.landlords {
+		if l.organisationID == organisationID && (l.record.IsArchived != nil) == archivedOnly {
+			landlords = append(landlords, l.record)
+		}
+	}
+
+	return landlords
+}
+
+func (r *LandlordRepository) Get(ctx context.Context, organisationID string, id string) (models.Landlord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.find(organisationID, id)
+
+	if i < 0 {
+		return models.Landlord{}, storage.ErrNotFound
+	}
+
+	return r.landlords[i].record, nil
+}
+
+func (r *LandlordRepository) GetMany(ctx context.Context, organisationID string, ids []string) ([]models.Landlord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	landlords := []models.Landlord{}
+
+	for _, id := range ids {
+		if i := r.find(organisationID, id); i >= 0 {
+			landlords = append(landlords, r.landlords[i].record)
+		}
+	}
+
+	return landlords, nil
+}
+
+func (r *LandlordRepository) Create(ctx context.Context, organisationID string, payload models.CreateLandlord) (models.Landlord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.Landlord{}, err
+	}
+
+	now := now(time.Time{})
+
+	record := models.Landlord{
+		Id:           &id,
+		Name:         payload.Name,
+		Email:        payload.Email,
+		Mobile:       payload.Mobile,
+		Phone:        payload.Phone,
+		AddressLine1: payload.AddressLine1,
+		AddressLine2: payload.AddressLine2,
+		Suburb:       payload.Suburb,
+		State:        payload.State,
+		Postcode:     payload.Postcode,
+		Country:      payload.Country,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	r.landlords = append(r.landlords, landlord{organisationID: organisationID, record: record})
+
+	return record, nil
+}
+
+func (r *LandlordRepository) Update(ctx context.Context, organisationID string, id string, payload models.UpdateLandlord, versions storage.Versions) (models.Landlord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, err := r.findVersion(organisationID, id, versions)
+
+	if err != nil {
+		return models.Landlord{}, err
+	}
+
+	record := &r.landlords[i].record
+
+	set(&record.Name, payload.Name)
+	set(&record.Email, payload.Email)
+	set(&record.Mobile, payload.Mobile)
+	set(&record.AddressLine1, payload.AddressLine1)
+	set(&record.Suburb, payload.Suburb)
+	set(&record.State, payload.State)
+	set(&record.Postcode, payload.Postcode)
+	set(&record.Country, payload.Country)
+
+	setNullable(&record.Phone, payload.Phone)
+	setNullable(&record.AddressLine2, payload.AddressLine2)
+	setNullable(&record.IsArchived, payload.IsArchived)
+
+	record.UpdatedAt = now(record.UpdatedAt)
+
+	return *record, nil
+}
+
+func (r *LandlordRepository) Archive(ctx context.Context, organisationID string, id string, versions storage.Versions) (models.Landlord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, err := r.findVersion(organisationID, id, versions)
+
+	if err != nil {
+		return models.Landlord{}, err
+	}
+
+	record := &r.landlords[i].record
+	record.UpdatedAt = now(record.UpdatedAt)
+
+	if record.IsArchived == nil {
+		record.IsArchived = &record.UpdatedAt
+	}
+
+	return *record, nil
+}
+
+// find returns the index of the organisation's landlord, or -1 when there isn't one
+func (r *LandlordRepository) find(organisationID string, id string) int {
+	return slices.IndexFunc(r.landlords, func(l landlord) bool {
+		return l.organisationID == organisationID && strings.EqualFold(l.record.Id.String(), id)
+	})
+}
+
+// findVersion finds the organisation's landlord, failing the same as a conditional
+// write when it isn't at one of the versions
+func (r *LandlordRepository) findVersion(organisationID string, id string, versions storage.Versions) (int, error) {
+	i := r.find(organisationID, id)
+
+	if i < 0 {
+		return i, storage.ErrNotFound
+	}
+
+	if len(versions) > 0 && !slices.ContainsFunc(versions, r.landlords[i].record.UpdatedAt.Equal) {
+		return i, storage.ErrPreconditionFailed
+	}
+
+	return i, nil
+}
+
+// set replaces a required field when the update includes it
+func set[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// setNullable replaces or clears an optional field when the update includes it
+func setNullable[T any](field **T, value nullable.Nullable[T]) {
+	if !value.IsSpecified() {
+		return
+	}
+
+	*field = nil
+
+	if v, err := value.Get(); err == nil {
+		*field = &v
+	}
+}
+
+// now returns the time a record is written, at the precision Postgres keeps. It's
+// always after the record's last version, so each write gets a new ETag.
+func now(last time.Time) time.Time {
+	t := time.Now().UTC().Truncate(time.Microsecond)
+
+	if !t.After(last) {
+		t = last.Add(time.Microsecond)
+	}
+
+	return t
+}
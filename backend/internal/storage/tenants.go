@@ -0,0 +1,533 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// TenantRepository reads and writes tenants within an organisation
+type TenantRepository interface {
+	List(ctx context.Context, organisationID string, filter TenantFilter) ([]models.Tenant, PageInfo, error)
+	// ListVersion returns the version of the records List would return for the filter,
+	// ignoring its page, sort and fields
+	ListVersion(ctx context.Context, organisationID string, filter TenantFilter) (ListVersion, error)
+	Get(ctx context.Context, organisationID string, id string) (models.Tenant, error)
+	Create(ctx context.Context, organisationID string, payload models.CreateTenant) (models.Tenant, error)
+	// Update and Archive fail with ErrPreconditionFailed when the tenant isn't at one of
+	// the given versions
+	Update(ctx context.Context, organisationID string, id string, payload models.UpdateTenant, versions Versions) (models.Tenant, error)
+	Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Tenant, error)
+
+	// ListTenanciesForPortalUser returns the tenancies on a landlord's properties, or
+	// a tenant's own tenancy
+	ListTenanciesForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.PortalTenancy, error)
+}
+
+// TenantFilter narrows and pages the tenants returned by List
+type TenantFilter struct {
+	Name               *string
+	ArchivedOnly       *bool
+	PropertyAssignedTo *string
+	PropertyID         Set
+	Frequency          Set
+	// PaidTo and EndDate are compared as dates
+	PaidTo       Range[time.Time]
+	EndDate      Range[time.Time]
+	RentalAmount Range[float64]
+	// Sort is the fields to sort by, e.g. -created_at for the newest first
+	Sort []string
+	// Fields limits the columns selected to these and id, all of them when empty
+	Fields []string
+	Page
+}
+
+// tenantColumns are selected in the order scanTenant expects
+const tenantColumns = `
+	id,
+	name,
+	email,
+	mobile,
+	phone,
+	paid_from,
+	paid_to,
+	rental_amount,
+	frequency,
+	original_start_date,
+	start_date,
+	end_date,
+	termination_date,
+	termination_reason,
+	vacate_date,
+	is_archived,
+	property_id,
+	created_at,
+	updated_at
+`
+
+// portalTenancyColumns are selected in the order scanPortalTenancy expects
+const portalTenancyColumns = `
+	id,
+	property_id,
+	name,
+	paid_from,
+	paid_to,
+	rental_amount,
+	frequency,
+	start_date,
+	end_date,
+	vacate_date
+`
+
+// dailyRent is a tenancy's rent per day, for pricing days in arrears. A month is
+// taken as a twelfth of a 365 day year.
+const dailyRent = `
+	CASE frequency
+		WHEN 'weekly' THEN rental_amount / 7
+		WHEN 'fortnightly' THEN rental_amount / 14
+		WHEN 'monthly' THEN rental_amount * 12 / 365
+	END
+`
+
+// activeTenancy is the condition for a tenant whose tenancy is current: not archived,
+// and not vacated before today in the timezone of the organisation whose ID is the
+// given parameter. It's the same rule the tenancy stats use.
+func activeTenancy(organisationParam int) string {
+	return fmt.Sprintf(`
+		is_archived IS NULL
+		AND (
+			vacate_date IS NULL
+			OR vacate_date >= (NOW() AT TIME ZONE COALESCE(
+				(SELECT timezone FROM organisations WHERE id = $%d),
+				'Australia/Sydney'
+			))::date
+		)
+	`, organisationParam)
+}
+
+// tenantKeys is the order lists of tenants are returned in when no sort is given
+var tenantKeys = keyset{{column: "name"}, {column: "id"}}
+
+type PostgresTenantRepository struct {
+	db querier
+}
+
+func NewPostgresTenantRepository(dbpool *pgxpool.Pool) *PostgresTenantRepository {
+	return &PostgresTenantRepository{db: dbpool}
+}
+
+func (r *PostgresTenantRepository) List(ctx context.Context, organisationID string, filter TenantFilter) ([]models.Tenant, PageInfo, error) {
+	keys, err := sortKeys(filter.Sort, tenantSortFields, tenantKeys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	columns, err := selectColumns(tenantColumns, tenantPlaceholders, filter.Fields, keys)
+
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	whereClause, queryParams, paramCount := buildWhereClause(tenantConditions(organisationID, filter))
+
+	return listPage(ctx, r.db, listQuery{
+		table:      "tenants",
+		columns:    columns,
+		where:      whereClause,
+		params:     queryParams,
+		paramCount: paramCount,
+		keys:       keys,
+	}, filter.Page, scanTenant)
+}
+
+func (r *PostgresTenantRepository) ListVersion(ctx context.Context, organisationID string, filter TenantFilter) (ListVersion, error) {
+	whereClause, queryParams, paramCount := buildWhereClause(tenantConditions(organisationID, filter))
+
+	return listVersion(ctx, r.db, "tenants", organisationID, whereClause, queryParams, paramCount)
+}
+
+// tenantConditions are the columns List matches the filter against
+func tenantConditions(organisationID string, filter TenantFilter) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 filter.Name,
+		"archived_only":        filter.ArchivedOnly,
+		"property_assigned_to": filter.PropertyAssignedTo,
+		"property_id":          filter.PropertyID,
+		"frequency":            filter.Frequency,
+		"paid_to":              filter.PaidTo,
+		"end_date":             filter.EndDate,
+		"rental_amount":        filter.RentalAmount,
+		"organisation_id":      organisationID,
+	}
+}
+
+func (r *PostgresTenantRepository) Get(ctx context.Context, organisationID string, id string) (models.Tenant, error) {
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM tenants
+		WHERE
+			id = $1
+			AND organisation_id = $2
+	`, tenantColumns)
+
+	row := r.db.QueryRow(ctx, sql, id, organisationID)
+
+	tenant, err := scanTenant(row)
+
+	return tenant, notFound(err)
+}
+
+func (r *PostgresTenantRepository) Create(ctx context.Context, organisationID string, payload models.CreateTenant) (models.Tenant, error) {
+	id, err := uuid.NewV7()
+
+	if err != nil {
+		return models.Tenant{}, err
+	}
+
+	sql := fmt.Sprintf(`
+		INSERT INTO tenants (
+			id,
+			name,
+			email,
+			mobile,
+			phone,
+			paid_from,
+			paid_to,
+			rental_amount,
+			frequency,
+			original_start_date,
+			start_date,
+			end_date,
+			property_id,
+			organisation_id
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12,
+			$13,
+			$14
+		) RETURNING %s
+	`, tenantColumns)
+
+	row := r.db.QueryRow(
+		ctx,
+		sql,
+		id.String(),
+		payload.Name,
+		payload.Email,
+		payload.Mobile,
+		payload.Phone,
+		payload.PaidTo,
+		payload.PaidTo,
+		payload.RentalAmount,
+		payload.Frequency,
+		payload.OriginalStartDate,
+		payload.StartDate,
+		payload.EndDate,
+		payload.PropertyId,
+		organisationID,
+	)
+
+	return scanTenant(row)
+}
+
+func (r *PostgresTenantRepository) Update(ctx context.Context, organisationID string, id string, payload models.UpdateTenant, versions Versions) (models.Tenant, error) {
+	setClause, values, paramCount := buildTenantUpdateSetClause(payload)
+	values = append(values, id, organisationID)
+
+	versionClause, versionParams := versions.and(paramCount + 3)
+	values = append(values, versionParams...)
+
+	sql := fmt.Sprintf(`
+		UPDATE tenants
+		%s
+		WHERE
+			id = $%d
+			AND organisation_id = $%d
+			%s
+		RETURNING %s
+	`, setClause, paramCount+1, paramCount+2, versionClause, tenantColumns)
+
+	row := r.db.QueryRow(ctx, sql, values...)
+
+	tenant, err := scanTenant(row)
+
+	return tenant, conditionalWriteError(ctx, r.db, "tenants", organisationID, id, versions, err)
+}
+
+func (r *PostgresTenantRepository) Archive(ctx context.Context, organisationID string, id string, versions Versions) (models.Tenant, error) {
+	versionClause, versionParams := versions.and(3)
+
+	sql := fmt.Sprintf(`
+		UPDATE tenants
+		SET
+			is_archived = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = $1
+			AND organisation_id = $2
+			%s
+		RETURNING %s
+	`, versionClause, tenantColumns)
+
+	row := r.db.QueryRow(ctx, sql, append([]interface{}{id, organisationID}, versionParams...)...)
+
+	tenant, err := scanTenant(row)
+
+	return tenant, conditionalWriteError(ctx, r.db, "tenants", organisationID, id, versions, err)
+}
+
+func (r *PostgresTenantRepository) ListTenanciesForPortalUser(ctx context.Context, organisationID string, portalUser models.PortalUser) ([]models.PortalTenancy, error) {
+	tenancies := []models.PortalTenancy{}
+
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM tenants
+		WHERE
+			organisation_id = $1
+			AND is_archived IS NULL
+			AND (
+				property_id IN (SELECT id FROM properties WHERE landlord_id = $2)
+				OR id = $3
+			)
+		ORDER BY start_date DESC
+	`, portalTenancyColumns)
+
+	rows, err := r.db.Query(
+		ctx,
+		sql,
+		organisationID,
+		portalUser.LandlordId,
+		portalUser.TenantId,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tenancy, err := scanPortalTenancy(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tenancies = append(tenancies, tenancy)
+	}
+
+	return tenancies, rows.Err()
+}
+
+func scanTenant(scanner scanner) (models.Tenant, error) {
+	var tenant models.Tenant
+	// paid_to and rental_amount can be null, and are returned as their zero values
+	var paidFrom pgtype.Date
+	var paidTo pgtype.Date
+	var rentalAmount *float64
+	var originalStartDate pgtype.Date
+	var startDate pgtype.Date
+	var endDate pgtype.Date
+	var terminationDate *pgtype.Date
+	var vacateDate *pgtype.Date
+
+	err := scanner.Scan(
+		&tenant.Id,
+		&tenant.Name,
+		&tenant.Email,
+		&tenant.Mobile,
+		&tenant.Phone,
+		&paidFrom,
+		&paidTo,
+		&rentalAmount,
+		&tenant.Frequency,
+		&originalStartDate,
+		&startDate,
+		&endDate,
+		&terminationDate,
+		&tenant.TerminationReason,
+		&vacateDate,
+		&tenant.IsArchived,
+		&tenant.PropertyId,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+
+	tenant.PaidFrom.Time = paidFrom.Time
+	tenant.PaidTo.Time = paidTo.Time
+	tenant.OriginalStartDate.Time = originalStartDate.Time
+	tenant.StartDate.Time = startDate.Time
+	tenant.EndDate.Time = endDate.Time
+
+	if terminationDate != nil {
+		tenant.TerminationDate = &openapi_types.Date{Time: terminationDate.Time}
+	}
+
+	if vacateDate != nil {
+		tenant.VacateDate = &openapi_types.Date{Time: vacateDate.Time}
+	}
+
+	if rentalAmount != nil {
+		tenant.RentalAmount = *rentalAmount
+	}
+
+	return tenant, err
+}
+
+func scanPortalTenancy(scanner scanner) (models.PortalTenancy, error) {
+	var tenancy models.PortalTenancy
+	var paidFrom pgtype.Date
+	var paidTo pgtype.Date
+	var rentalAmount *float64
+	var startDate pgtype.Date
+	var endDate pgtype.Date
+	var vacateDate *pgtype.Date
+
+	err := scanner.Scan(
+		&tenancy.Id,
+		&tenancy.PropertyId,
+		&tenancy.Name,
+		&paidFrom,
+		&paidTo,
+		&rentalAmount,
+		&tenancy.Frequency,
+		&startDate,
+		&endDate,
+		&vacateDate,
+	)
+
+	tenancy.PaidFrom.Time = paidFrom.Time
+	tenancy.PaidTo.Time = paidTo.Time
+	tenancy.StartDate.Time = startDate.Time
+	tenancy.EndDate.Time = endDate.Time
+
+	if vacateDate != nil {
+		tenancy.VacateDate = &openapi_types.Date{Time: vacateDate.Time}
+	}
+
+	if rentalAmount != nil {
+		tenancy.RentalAmount = *rentalAmount
+	}
+
+	return tenancy, err
+}
+
+func buildTenantUpdateSetClause(payload models.UpdateTenant) (string, []interface{}, int) {
+	fields := []string{}
+	values := []interface{}{}
+	paramCount := 0
+
+	if payload.Name != nil && *payload.Name != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("name = $%d", paramCount))
+		values = append(values, *payload.Name)
+	}
+
+	if payload.Email != nil && *payload.Email != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("email = $%d", paramCount))
+		values = append(values, *payload.Email)
+	}
+
+	if payload.Mobile != nil && *payload.Mobile != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("mobile = $%d", paramCount))
+		values = append(values, *payload.Mobile)
+	}
+
+	if payload.Phone != nil && *payload.Phone != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("phone = $%d", paramCount))
+		values = append(values, *payload.Phone)
+	}
+
+	if payload.PaidFrom != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("paid_from = $%d", paramCount))
+		values = append(values, *payload.PaidFrom)
+	}
+
+	if payload.PaidTo != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("paid_to = $%d", paramCount))
+		values = append(values, *payload.PaidTo)
+	}
+
+	if payload.RentalAmount != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("rental_amount = $%d", paramCount))
+		values = append(values, *payload.RentalAmount)
+	}
+
+	if payload.Frequency != nil && *payload.Frequency != "" {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("frequency = $%d", paramCount))
+		values = append(values, *payload.Frequency)
+	}
+
+	if payload.OriginalStartDate != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("original_start_date = $%d", paramCount))
+		values = append(values, *payload.OriginalStartDate)
+	}
+
+	if payload.StartDate != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("start_date = $%d", paramCount))
+		values = append(values, *payload.StartDate)
+	}
+
+	if payload.EndDate != nil {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("end_date = $%d", paramCount))
+		values = append(values, *payload.EndDate)
+	}
+
+	if payload.TerminationDate.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("termination_date = $%d", paramCount))
+		values = append(values, nullableValue(payload.TerminationDate))
+	}
+
+	if payload.TerminationReason.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("termination_reason = $%d", paramCount))
+		values = append(values, nullableValue(payload.TerminationReason))
+	}
+
+	if payload.VacateDate.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("vacate_date = $%d", paramCount))
+		values = append(values, nullableValue(payload.VacateDate))
+	}
+
+	if payload.IsArchived.IsSpecified() {
+		paramCount++
+		fields = append(fields, fmt.Sprintf("is_archived = $%d", paramCount))
+		values = append(values, nullableValue(payload.IsArchived))
+	}
+
+	fields = append(fields, "updated_at = NOW()")
+
+	setClause := "SET\n" + strings.Join(fields, ",\n")
+
+	return setClause, values, paramCount
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RecordRepositories are the repositories for the records that can be written in
+// batches, and for the responses stored with the records they create
+type RecordRepositories struct {
+	Landlords   LandlordRepository
+	Properties  PropertyRepository
+	Tenants     TenantRepository
+	Idempotency IdempotencyRepository
+}
+
+// Transactor runs a group of reads and writes in one transaction
+type Transactor interface {
+	// InTx runs fn with repositories that read and write in a transaction. It's
+	// committed when fn returns nil, and rolled back when it returns an error.
+	InTx(ctx context.Context, fn func(RecordRepositories) error) error
+}
+
+type PostgresTransactor struct {
+	dbpool *pgxpool.Pool
+}
+
+func NewPostgresTransactor(dbpool *pgxpool.Pool) *PostgresTransactor {
+	return &PostgresTransactor{dbpool: dbpool}
+}
+
+func (t *PostgresTransactor) InTx(ctx context.Context, fn func(RecordRepositories) error) error {
+	return pgx.BeginFunc(ctx, t.dbpool, func(tx pgx.Tx) error {
+		return fn(RecordRepositories{
+			Landlords:   &PostgresLandlordRepository{db: tx},
+			Properties:  &PostgresPropertyRepository{db: tx},
+			Tenants:     &PostgresTenantRepository{db: tx},
+			Idempotency: &PostgresIdempotencyRepository{db: tx},
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davidtaing/property-management/internal/models"
+	"github.com/oapi-codegen/nullable"
+)
+
+func TestBuildLandlordUpdateSetClause(t *testing.T) {
+	name := "Jane Citizen"
+	archivedAt := time.Date(2025, 7, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		payload    models.UpdateLandlord
+		want       string
+		wantValues []any
+	}{
+		{"unrelated field", models.UpdateLandlord{Name: &name}, "SET\nname = $1,\nupdated_at = NOW()", []any{name}},
+		{"cleared field", models.UpdateLandlord{Phone: nullable.NewNullNullable[string]()}, "SET\nphone = $1,\nupdated_at = NOW()", []any{(*string)(nil)}},
+		{"set field", models.UpdateLandlord{Phone: nullable.NewNullableWithValue("0299999999")}, "SET\nphone = $1,\nupdated_at = NOW()", []any{ptr("0299999999")}},
+		{"archived", models.UpdateLandlord{IsArchived: nullable.NewNullableWithValue(archivedAt)}, "SET\nis_archived = $1,\nupdated_at = NOW()", []any{&archivedAt}},
+		{"unarchived", models.UpdateLandlord{IsArchived: nullable.NewNullNullable[time.Time]()}, "SET\nis_archived = $1,\nupdated_at = NOW()", []any{(*time.Time)(nil)}},
+		{"empty", models.UpdateLandlord{}, "SET\nupdated_at = NOW()", []any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, values, _ := buildLandlordUpdateSetClause(tt.payload)
+
+			if got != tt.want || !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("expected %q %v, got %q %v", tt.want, tt.wantValues, got, values)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
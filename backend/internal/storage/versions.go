@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrPreconditionFailed is returned when a write is limited to versions of a record
+// and the record has changed since
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// Versions limits a write to a record at one of these versions, identified by its
+// updated_at. Every version is allowed when it's empty.
+type Versions []time.Time
+
+// and returns the condition to add to a write's WHERE clause and its parameter,
+// which is numbered paramCount
+func (v Versions) and(paramCount int) (string, []interface{}) {
+	if len(v) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("AND updated_at = ANY($%d)", paramCount), []interface{}{[]time.Time(v)}
+}
+
+// conditionalWriteError maps the error from a write limited to versions. When no
+// record was written it finds out whether the record changed or never existed.
+func conditionalWriteError(ctx context.Context, db querier, table string, organisationID string, id string, versions Versions, err error) error {
+	if !errors.Is(err, pgx.ErrNoRows) || len(versions) == 0 {
+		return notFound(err)
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM %s
+			WHERE
+				id = $1
+				AND organisation_id = $2
+		)
+	`, table)
+
+	var exists bool
+
+	if err := db.QueryRow(ctx, sql, id, organisationID).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return ErrPreconditionFailed
+	}
+
+	return ErrNotFound
+}
+
+// ListVersion identifies the state of the records matching a filter. It changes
+// whenever one of them is created, changed or archived.
+type ListVersion struct {
+	// Count is the number of records matching the filter, and UpdatedAt when one of
+	// them last changed
+	Count     int
+	UpdatedAt time.Time
+	// LastModified is when any of the organisation's records last changed, including
+	// ones that no longer match the filter, so it never goes backwards. It's zero when
+	// there are none.
+	LastModified time.Time
+}
+
+// listVersion returns the version of the records matching a WHERE clause built by
+// buildWhereClause
+func listVersion(ctx context.Context, db querier, table string, organisationID string, where string, params []interface{}, paramCount int) (ListVersion, error) {
+	sql := fmt.Sprintf(`
+		SELECT
+			COUNT(*),
+			MAX(updated_at),
+			(SELECT MAX(updated_at) FROM %s WHERE organisation_id = $%d)
+		FROM %s
+		%s
+	`, table, paramCount, table, where)
+
+	var version ListVersion
+	var updatedAt, lastModified *time.Time
+
+	if err := db.QueryRow(ctx, sql, append(params, organisationID)...).Scan(&version.Count, &updatedAt, &lastModified); err != nil {
+		return ListVersion{}, err
+	}
+
+	if updatedAt != nil {
+		version.UpdatedAt = *updatedAt
+	}
+
+	if lastModified != nil {
+		version.LastModified = *lastModified
+	}
+
+	return version, nil
+}
@@ -0,0 +1,194 @@
+// Package testdb provides disposable Postgres databases for integration tests.
+//
+// When TEST_DATABASE_URL is set, a new database is created on that server for the
+// test run and dropped afterwards. Otherwise a throwaway cluster is started with the
+// initdb and pg_ctl binaries on the PATH. initdb refuses to run as root, so use
+// TEST_DATABASE_URL in containers.
+//
+// Without either the tests are skipped, except when CI is set, so a misconfigured
+// pipeline fails rather than passing without running them.
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/davidtaing/property-management/migrations"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// ErrUnavailable is returned by Start when there's no Postgres to test against
+var ErrUnavailable = errors.New("no Postgres available: set TEST_DATABASE_URL or put initdb and pg_ctl on the PATH")
+
+// unavailable returns ErrUnavailable, or an error the tests don't skip on in CI
+func unavailable() error {
+	if os.Getenv("CI") != "" {
+		return errors.New("CI is set but there's no Postgres to test against: set TEST_DATABASE_URL or put initdb and pg_ctl on the PATH")
+	}
+
+	return ErrUnavailable
+}
+
+// DB is a database that only exists for the duration of a test run
+type DB struct {
+	// URL is the connection string of the database
+	URL string
+
+	close func(ctx context.Context) error
+}
+
+// Start creates an empty database, see the package docs for where it's created
+func Start(ctx context.Context) (*DB, error) {
+	if serverURL := os.Getenv("TEST_DATABASE_URL"); serverURL != "" {
+		return createDatabase(ctx, serverURL)
+	}
+
+	return startCluster(ctx)
+}
+
+// Close drops the database, or stops the cluster it was created in
+func (db *DB) Close(ctx context.Context) error {
+	return db.close(ctx)
+}
+
+// Migrate applies the goose migrations to the database
+func (db *DB) Migrate(ctx context.Context) error {
+	conn, err := sql.Open("pgx", db.URL)
+
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, conn, migrations.FS)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = provider.Up(ctx)
+
+	return err
+}
+
+func createDatabase(ctx context.Context, serverURL string) (*DB, error) {
+	u, err := url.Parse(serverURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("TEST_DATABASE_URL must be a postgres:// URL: %w", err)
+	}
+
+	name := "property_management_test_" + uuid.NewString()[:8]
+
+	if err := execServer(ctx, serverURL, "CREATE DATABASE "+name); err != nil {
+		return nil, err
+	}
+
+	u.Path = "/" + name
+
+	return &DB{
+		URL: u.String(),
+		close: func(ctx context.Context) error {
+			return execServer(ctx, serverURL, fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", name))
+		},
+	}, nil
+}
+
+func execServer(ctx context.Context, serverURL string, sql string) error {
+	conn, err := pgx.Connect(ctx, serverURL)
+
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, sql)
+
+	return err
+}
+
+func startCluster(ctx context.Context) (*DB, error) {
+	initdb, err := exec.LookPath("initdb")
+
+	if err != nil {
+		return nil, unavailable()
+	}
+
+	pgCtl, err := exec.LookPath("pg_ctl")
+
+	if err != nil {
+		return nil, unavailable()
+	}
+
+	dir, err := os.MkdirTemp("", "property-management-test-")
+
+	if err != nil {
+		return nil, err
+	}
+
+	dataDir := filepath.Join(dir, "data")
+
+	out, err := exec.CommandContext(ctx, initdb, "-D", dataDir, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync").CombinedOutput()
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("initdb: %w\n%s", err, out)
+	}
+
+	port, err := freePort()
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	// fsync is off as the cluster is thrown away after the run
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -F", port, dir)
+
+	out, err = exec.CommandContext(ctx, pgCtl, "-D", dataDir, "-o", options, "-l", filepath.Join(dir, "postgres.log"), "-w", "start").CombinedOutput()
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("pg_ctl start: %w\n%s", err, out)
+	}
+
+	return &DB{
+		URL: fmt.Sprintf("postgres://postgres@127.0.0.1:%d/postgres?sslmode=disable", port),
+		close: func(ctx context.Context) error {
+			defer os.RemoveAll(dir)
+
+			ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+
+			out, err := exec.CommandContext(ctx, pgCtl, "-D", dataDir, "-m", "immediate", "-w", "stop").CombinedOutput()
+
+			if err != nil {
+				return fmt.Errorf("pg_ctl stop: %w\n%s", err, out)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,84 @@
+// Package tracing sets up OpenTelemetry tracing for HTTP requests and database queries
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidtaing/property-management/internal/config"
+	"github.com/exaring/otelpgx"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const serviceName = "property-management-api"
+
+// Setup installs the global tracer provider and W3C trace context propagation. When
+// the exporter is none, trace context is still propagated but no spans are recorded.
+// shutdown flushes any spans that haven't been exported yet.
+func Setup(ctx context.Context, cfg *config.Config, serviceVersion string) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+
+	switch cfg.TracesExporter {
+	case "", config.TracesExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case config.TracesExporterOTLP:
+		var options []otlptracehttp.Option
+
+		if cfg.OTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+
+		exporter, err = otlptracehttp.New(ctx, options...)
+	case config.TracesExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		err = fmt.Errorf("unknown traces exporter %q", cfg.TracesExporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", serviceVersion),
+		attribute.String("deployment.environment", cfg.Env),
+	))
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Middleware starts a span for each request, named after the route template, and
+// continues the caller's trace when a traceparent header is sent
+func Middleware() mux.MiddlewareFunc {
+	return otelmux.Middleware(serviceName)
+}
+
+// NewQueryTracer returns a pgx tracer that records a span for each query. Query
+// arguments aren't recorded as they contain personal details.
+func NewQueryTracer() pgx.QueryTracer {
+	return otelpgx.NewTracer(otelpgx.WithTrimSQLInSpanName())
+}
@@ -6,4 +6,6 @@ const (
 	OrgIDKey   ContextKey = "org_id"
 	OrgRoleKey ContextKey = "org_role"
 	UserIDKey  ContextKey = "user_id"
+
+	RequestIDKey ContextKey = "request_id"
 )
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE landlords ADD CONSTRAINT landlords_id_organisation_id_key UNIQUE (id, organisation_id);
+ALTER TABLE properties ADD CONSTRAINT properties_id_organisation_id_key UNIQUE (id, organisation_id);
+
+ALTER TABLE properties DROP CONSTRAINT properties_landlord_id_fkey;
+ALTER TABLE properties
+  ADD CONSTRAINT properties_landlord_id_organisation_id_fkey
+  FOREIGN KEY (landlord_id, organisation_id) REFERENCES landlords(id, organisation_id);
+
+ALTER TABLE tenants DROP CONSTRAINT tenants_property_id_fkey;
+ALTER TABLE tenants
+  ADD CONSTRAINT tenants_property_id_organisation_id_fkey
+  FOREIGN KEY (property_id, organisation_id) REFERENCES properties(id, organisation_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tenants DROP CONSTRAINT tenants_property_id_organisation_id_fkey;
+ALTER TABLE tenants
+  ADD CONSTRAINT tenants_property_id_fkey
+  FOREIGN KEY (property_id) REFERENCES properties(id);
+
+ALTER TABLE properties DROP CONSTRAINT properties_landlord_id_organisation_id_fkey;
+ALTER TABLE properties
+  ADD CONSTRAINT properties_landlord_id_fkey
+  FOREIGN KEY (landlord_id) REFERENCES landlords(id);
+
+ALTER TABLE properties DROP CONSTRAINT properties_id_organisation_id_key;
+ALTER TABLE landlords DROP CONSTRAINT landlords_id_organisation_id_key;
+-- +goose StatementEnd
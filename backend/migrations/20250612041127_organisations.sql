@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organisations (
+    id TEXT PRIMARY KEY,
+    trading_name TEXT,
+    licence_number TEXT,
+    abn TEXT,
+    timezone TEXT NOT NULL DEFAULT 'Australia/Sydney',
+    currency TEXT NOT NULL DEFAULT 'AUD',
+    default_management_fee DECIMAL(18, 2),
+    default_inspection_interval INTEGER,
+    logo_url TEXT,
+    primary_colour TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO organisations (id)
+SELECT organisation_id FROM landlords
+UNION
+SELECT organisation_id FROM properties
+UNION
+SELECT organisation_id FROM tenants;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE organisations;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE properties ADD COLUMN assigned_to TEXT;
+CREATE INDEX idx_properties_organisation_id_assigned_to ON properties(organisation_id, assigned_to);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_properties_organisation_id_assigned_to;
+ALTER TABLE properties DROP COLUMN assigned_to;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tenants ADD CONSTRAINT tenants_id_organisation_id_key UNIQUE (id, organisation_id);
+
+CREATE TABLE portal_users (
+    id UUID PRIMARY KEY,
+    organisation_id TEXT NOT NULL,
+    user_id TEXT NOT NULL UNIQUE,
+    landlord_id UUID,
+    tenant_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT portal_users_landlord_or_tenant_check CHECK (num_nonnulls(landlord_id, tenant_id) = 1),
+    CONSTRAINT portal_users_landlord_id_organisation_id_fkey
+      FOREIGN KEY (landlord_id, organisation_id) REFERENCES landlords(id, organisation_id),
+    CONSTRAINT portal_users_tenant_id_organisation_id_fkey
+      FOREIGN KEY (tenant_id, organisation_id) REFERENCES tenants(id, organisation_id)
+);
+CREATE INDEX idx_portal_users_organisation_id ON portal_users(organisation_id);
+
+CREATE TYPE maintenance_status AS ENUM ('open', 'in_progress', 'completed', 'cancelled');
+
+CREATE TABLE maintenance_requests (
+    id UUID PRIMARY KEY,
+    organisation_id TEXT NOT NULL,
+    property_id UUID NOT NULL,
+    tenant_id UUID,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status maintenance_status NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT maintenance_requests_property_id_organisation_id_fkey
+      FOREIGN KEY (property_id, organisation_id) REFERENCES properties(id, organisation_id),
+    CONSTRAINT maintenance_requests_tenant_id_organisation_id_fkey
+      FOREIGN KEY (tenant_id, organisation_id) REFERENCES tenants(id, organisation_id)
+);
+CREATE INDEX idx_maintenance_requests_organisation_id ON maintenance_requests(organisation_id);
+CREATE INDEX idx_maintenance_requests_property_id ON maintenance_requests(property_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE maintenance_requests;
+DROP TYPE maintenance_status;
+DROP TABLE portal_users;
+ALTER TABLE tenants DROP CONSTRAINT tenants_id_organisation_id_key;
+-- +goose StatementEnd
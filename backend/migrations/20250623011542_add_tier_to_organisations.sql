@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE organisations ADD COLUMN tier TEXT NOT NULL DEFAULT 'standard';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE organisations DROP COLUMN tier;
+-- +goose StatementEnd
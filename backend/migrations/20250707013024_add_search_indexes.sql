@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX landlords_email_trgm_idx ON landlords USING GIN (email gin_trgm_ops);
+CREATE INDEX landlords_mobile_digits_trgm_idx ON landlords USING GIN ((regexp_replace(mobile, '[^0-9]', '', 'g')) gin_trgm_ops);
+CREATE INDEX tenants_email_trgm_idx ON tenants USING GIN (email gin_trgm_ops);
+CREATE INDEX tenants_mobile_digits_trgm_idx ON tenants USING GIN ((regexp_replace(mobile, '[^0-9]', '', 'g')) gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tenants_mobile_digits_trgm_idx;
+DROP INDEX IF EXISTS tenants_email_trgm_idx;
+DROP INDEX IF EXISTS landlords_mobile_digits_trgm_idx;
+DROP INDEX IF EXISTS landlords_email_trgm_idx;
+-- +goose StatementEnd
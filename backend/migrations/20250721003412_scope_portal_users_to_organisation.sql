@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE portal_users DROP CONSTRAINT portal_users_user_id_key;
+ALTER TABLE portal_users ADD CONSTRAINT portal_users_organisation_id_user_id_key UNIQUE (organisation_id, user_id);
+CREATE INDEX idx_portal_users_user_id ON portal_users(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_portal_users_user_id;
+ALTER TABLE portal_users DROP CONSTRAINT portal_users_organisation_id_user_id_key;
+ALTER TABLE portal_users ADD CONSTRAINT portal_users_user_id_key UNIQUE (user_id);
+-- +goose StatementEnd
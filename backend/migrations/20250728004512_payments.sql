@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE payments (
+    id UUID PRIMARY KEY,
+    organisation_id TEXT NOT NULL,
+    tenant_id UUID NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL,
+    received_on DATE NOT NULL,
+    paid_from DATE NOT NULL,
+    paid_to DATE NOT NULL,
+    reference TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT payments_amount_check CHECK (amount > 0),
+    CONSTRAINT payments_tenant_id_organisation_id_fkey
+      FOREIGN KEY (tenant_id, organisation_id) REFERENCES tenants(id, organisation_id)
+);
+CREATE INDEX idx_payments_tenant_id ON payments(tenant_id);
+CREATE INDEX idx_payments_organisation_id_received_on ON payments(organisation_id, received_on);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE payments;
+-- +goose StatementEnd
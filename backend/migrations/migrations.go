@@ -0,0 +1,7 @@
+// Package migrations embeds the goose SQL migrations so they can be applied from Go
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS